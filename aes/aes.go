@@ -4,7 +4,15 @@ import (
 	"bytes"
 	caes "crypto/aes"
 	"crypto/cipher"
+	"crypto/subtle"
 	"encoding/base64"
+	"errors"
+)
+
+var (
+	ErrInvalidPadding   = errors.New("aes: invalid padding")
+	ErrInvalidBlockSize = errors.New("aes: ciphertext is not a multiple of the block size")
+	ErrInvalidIV        = errors.New("aes: iv length must equal the block size")
 )
 
 type AES struct{}
@@ -33,6 +41,10 @@ func (a *AESki) Encrypt(encryptStr string) (string, error) {
 	}
 
 	blockSize := block.BlockSize()
+	if len(a.iv) != blockSize {
+		return "", ErrInvalidIV
+	}
+
 	encryptBytes = pkcs5Padding(encryptBytes, blockSize)
 
 	blockMode := cipher.NewCBCEncrypter(block, []byte(a.iv))
@@ -52,11 +64,27 @@ func (a *AESki) Decrypt(decryptStr string) (string, error) {
 		return "", err
 	}
 
+	blockSize := block.BlockSize()
+	if len(a.iv) != blockSize {
+		return "", ErrInvalidIV
+	}
+
+	if len(decryptBytes) < blockSize {
+		return "", ErrCiphertextTooShort
+	}
+
+	if len(decryptBytes)%blockSize != 0 {
+		return "", ErrInvalidBlockSize
+	}
+
 	blockMode := cipher.NewCBCDecrypter(block, []byte(a.iv))
 	decrypted := make([]byte, len(decryptBytes))
 
 	blockMode.CryptBlocks(decrypted, decryptBytes)
-	decrypted = pkcs5UnPadding(decrypted)
+	decrypted, err = pkcs5UnPadding(decrypted, blockSize)
+	if err != nil {
+		return "", err
+	}
 	return string(decrypted), nil
 }
 
@@ -66,8 +94,31 @@ func pkcs5Padding(cipherText []byte, blockSize int) []byte {
 	return append(cipherText, padText...)
 }
 
-func pkcs5UnPadding(decrypted []byte) []byte {
+// pkcs5UnPadding checks the padding without branching on its content, so
+// timing does not reveal which byte was wrong.
+func pkcs5UnPadding(decrypted []byte, blockSize int) ([]byte, error) {
 	length := len(decrypted)
-	unPadding := int(decrypted[length-1])
-	return decrypted[:(length - unPadding)]
+	if length == 0 || length%blockSize != 0 {
+		return nil, ErrInvalidPadding
+	}
+
+	unPadding := decrypted[length-1]
+
+	// good stays 1 only if 1 <= unPadding <= blockSize and every one of the
+	// last unPadding bytes equals unPadding.
+	good := subtle.ConstantTimeLessOrEq(1, int(unPadding))
+	good &= subtle.ConstantTimeLessOrEq(int(unPadding), blockSize)
+
+	last := decrypted[length-blockSize:]
+	for i := 0; i < blockSize; i++ {
+		inPad := subtle.ConstantTimeLessOrEq(blockSize-i, int(unPadding))
+		match := subtle.ConstantTimeByteEq(last[i], unPadding)
+		good &= subtle.ConstantTimeSelect(inPad, match, 1)
+	}
+
+	if good != 1 {
+		return nil, ErrInvalidPadding
+	}
+
+	return decrypted[:length-int(unPadding)], nil
 }
@@ -1,6 +1,11 @@
 package aes
 
-import "testing"
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
 
 const (
 	key = "IgkibX71IEf382PT"
@@ -28,3 +33,83 @@ func BenchmarkEncryptAndDecrypt(b *testing.B) {
 		_, _ = a.Decrypt(encryptString)
 	}
 }
+
+func TestDecryptErrors(t *testing.T) {
+	a := New().RegisterKeys(key, iv)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  error
+	}{
+		{"empty", []byte{}, ErrCiphertextTooShort},
+		{"short", []byte("abc"), ErrCiphertextTooShort},
+		{"not block aligned", bytes.Repeat([]byte{1}, 20), ErrInvalidBlockSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.Decrypt(base64.URLEncoding.EncodeToString(tt.input))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := New().RegisterKeys(key, "short").Decrypt("GO-ri84zevE-z1biJwfQPw=="); !errors.Is(err, ErrInvalidIV) {
+		t.Errorf("expected ErrInvalidIV, got %v", err)
+	}
+}
+
+func TestPkcs5UnPadding(t *testing.T) {
+	block := func(tail ...byte) []byte {
+		b := bytes.Repeat([]byte{'x'}, 16-len(tail))
+		return append(b, tail...)
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+		err   error
+	}{
+		{"one byte", block(1), "xxxxxxxxxxxxxxx", nil},
+		{"full block", bytes.Repeat([]byte{16}, 16), "", nil},
+		{"zero", block(0), "", ErrInvalidPadding},
+		{"too large", block(17), "", ErrInvalidPadding},
+		{"mismatch", block(2, 3, 3), "", ErrInvalidPadding},
+		{"empty", []byte{}, "", ErrInvalidPadding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pkcs5UnPadding(tt.input, 16)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	a := New().RegisterKeys(key, iv)
+
+	for _, s := range []string{"", "123456", "exactly16bytes!!"} {
+		enc, err := a.Encrypt(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dec, err := a.Decrypt(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if dec != s {
+			t.Errorf("expected %q, got %q", s, dec)
+		}
+	}
+}
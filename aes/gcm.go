@@ -0,0 +1,96 @@
+package aes
+
+import (
+	caes "crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+var ErrCiphertextTooShort = errors.New("aes: ciphertext too short")
+
+// AESgcm encrypts with AES-GCM. Every message gets a fresh random nonce,
+// which is prepended to the ciphertext.
+type AESgcm struct {
+	key string
+}
+
+func (a *AES) RegisterGCMKey(key string) *AESgcm {
+	return &AESgcm{
+		key: key,
+	}
+}
+
+func (g *AESgcm) Encrypt(encryptStr string) (string, error) {
+	return g.EncryptWithAD(encryptStr, nil)
+}
+
+func (g *AESgcm) Decrypt(decryptStr string) (string, error) {
+	return g.DecryptWithAD(decryptStr, nil)
+}
+
+// EncryptWithAD encrypts encryptStr binding it to additionalData, which must
+// be supplied again on decryption but is not part of the output.
+func (g *AESgcm) EncryptWithAD(encryptStr string, additionalData []byte) (string, error) {
+	sealed, err := g.Seal([]byte(encryptStr), additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(sealed), nil
+}
+
+func (g *AESgcm) DecryptWithAD(decryptStr string, additionalData []byte) (string, error) {
+	sealed, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := g.Open(sealed, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// Seal returns nonce || ciphertext || tag as raw bytes.
+func (g *AESgcm) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := g.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open reverses Seal, failing if the ciphertext or additionalData were tampered with.
+func (g *AESgcm) Open(sealed, additionalData []byte) ([]byte, error) {
+	aead, err := g.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrCiphertextTooShort
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func (g *AESgcm) aead() (cipher.AEAD, error) {
+	block, err := caes.NewCipher([]byte(g.key))
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,75 @@
+package aes
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+const gcmKey = "IgkibX71IEf382PTIgkibX71IEf382PT"
+
+func TestGCMEncryptDecrypt(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+
+	enc, err := g.Encrypt("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dec, err := g.Decrypt(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dec != "123456" {
+		t.Errorf("expected 123456, got %s", dec)
+	}
+}
+
+func TestGCMRandomNonce(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+
+	a, _ := g.Encrypt("same")
+	b, _ := g.Encrypt("same")
+	if a == b {
+		t.Error("expected different ciphertexts for the same plaintext")
+	}
+}
+
+func TestGCMAdditionalData(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+
+	enc, err := g.EncryptWithAD("token", []byte("order-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.DecryptWithAD(enc, []byte("order-2")); err == nil {
+		t.Error("expected error with wrong additional data")
+	}
+
+	dec, err := g.DecryptWithAD(enc, []byte("order-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dec != "token" {
+		t.Errorf("expected token, got %s", dec)
+	}
+}
+
+func TestGCMTamper(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+
+	enc, _ := g.Encrypt("123456")
+	raw, _ := base64.URLEncoding.DecodeString(enc)
+	raw[len(raw)-1] ^= 0x01
+
+	if _, err := g.Decrypt(base64.URLEncoding.EncodeToString(raw)); err == nil {
+		t.Error("expected error for tampered ciphertext")
+	}
+
+	if _, err := g.Open(raw[:4], nil); !errors.Is(err, ErrCiphertextTooShort) {
+		t.Errorf("expected ErrCiphertextTooShort, got %v", err)
+	}
+}
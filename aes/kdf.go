@@ -0,0 +1,281 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Passphrase layout: magic(4) | kdf(1) | params(9) | salt(16) | payload.
+// Params are Iterations, N/Time and R/Memory as uint32 followed by P/Threads
+// as a single byte; unused fields are zero. The header is authenticated.
+const (
+	passMagic      = "XAP\x01"
+	passSaltSize   = 16
+	passParamsSize = 9
+	passHeaderSize = len(passMagic) + 1 + passParamsSize + passSaltSize
+	derivedKeySize = 32
+)
+
+type KDF byte
+
+const (
+	KDFPBKDF2 KDF = iota + 1
+	KDFScrypt
+	KDFArgon2id
+)
+
+// Upper bounds accepted when reading parameters from a ciphertext, so a
+// crafted header cannot make decryption allocate or spin without limit.
+// scrypt needs 128*N*r bytes and Argon2id Memory KiB; both are held to 1 GiB.
+const (
+	maxPBKDF2Iterations = 10_000_000
+	maxScryptN          = 1 << 22
+	maxScryptR          = 32
+	maxScryptP          = 16
+	maxScryptMemory     = 1 << 30
+	maxArgon2Memory     = 1024 * 1024
+	maxArgon2Time       = 100
+)
+
+var (
+	ErrInvalidKDFParams      = errors.New("aes: invalid key derivation parameters")
+	ErrInvalidPassphraseData = errors.New("aes: invalid passphrase ciphertext")
+)
+
+// KDFParams selects the key derivation function and its cost. Only the
+// fields used by KDF are read.
+type KDFParams struct {
+	KDF KDF
+
+	// PBKDF2-HMAC-SHA256
+	Iterations uint32
+
+	// scrypt; N must be a power of two
+	N uint32
+	R uint32
+	P uint8
+
+	// Argon2id; Memory is in KiB
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+}
+
+func DefaultPBKDF2Params() KDFParams {
+	return KDFParams{KDF: KDFPBKDF2, Iterations: 600_000}
+}
+
+func DefaultScryptParams() KDFParams {
+	return KDFParams{KDF: KDFScrypt, N: 1 << 15, R: 8, P: 1}
+}
+
+func DefaultArgon2idParams() KDFParams {
+	return KDFParams{KDF: KDFArgon2id, Time: 3, Memory: 64 * 1024, Threads: 4}
+}
+
+func (p KDFParams) validate() error {
+	switch p.KDF {
+	case KDFPBKDF2:
+		if p.Iterations == 0 || p.Iterations > maxPBKDF2Iterations {
+			return fmt.Errorf("%w: pbkdf2 iterations %d", ErrInvalidKDFParams, p.Iterations)
+		}
+	case KDFScrypt:
+		if p.N < 2 || p.N&(p.N-1) != 0 || p.N > maxScryptN ||
+			p.R == 0 || p.R > maxScryptR || p.P == 0 || p.P > maxScryptP {
+			return fmt.Errorf("%w: scrypt N=%d r=%d p=%d", ErrInvalidKDFParams, p.N, p.R, p.P)
+		}
+		if 128*uint64(p.N)*uint64(p.R) > maxScryptMemory {
+			return fmt.Errorf("%w: scrypt N=%d r=%d needs more than 1 GiB", ErrInvalidKDFParams, p.N, p.R)
+		}
+	case KDFArgon2id:
+		if p.Time == 0 || p.Time > maxArgon2Time || p.Threads == 0 ||
+			p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory {
+			return fmt.Errorf("%w: argon2id t=%d m=%d p=%d", ErrInvalidKDFParams, p.Time, p.Memory, p.Threads)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kdf %d", ErrInvalidKDFParams, p.KDF)
+	}
+	return nil
+}
+
+func (p KDFParams) marshal() []byte {
+	b := make([]byte, passParamsSize)
+	switch p.KDF {
+	case KDFPBKDF2:
+		binary.BigEndian.PutUint32(b[0:4], p.Iterations)
+	case KDFScrypt:
+		binary.BigEndian.PutUint32(b[0:4], p.N)
+		binary.BigEndian.PutUint32(b[4:8], p.R)
+		b[8] = p.P
+	case KDFArgon2id:
+		binary.BigEndian.PutUint32(b[0:4], p.Time)
+		binary.BigEndian.PutUint32(b[4:8], p.Memory)
+		b[8] = p.Threads
+	}
+	return b
+}
+
+func unmarshalKDFParams(kdf KDF, b []byte) KDFParams {
+	p := KDFParams{KDF: kdf}
+	switch kdf {
+	case KDFPBKDF2:
+		p.Iterations = binary.BigEndian.Uint32(b[0:4])
+	case KDFScrypt:
+		p.N = binary.BigEndian.Uint32(b[0:4])
+		p.R = binary.BigEndian.Uint32(b[4:8])
+		p.P = b[8]
+	case KDFArgon2id:
+		p.Time = binary.BigEndian.Uint32(b[0:4])
+		p.Memory = binary.BigEndian.Uint32(b[4:8])
+		p.Threads = b[8]
+	}
+	return p
+}
+
+// DeriveKey derives a 32 byte AES-256 key from passphrase and salt.
+func (a *AES) DeriveKey(passphrase string, salt []byte, params KDFParams) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	switch params.KDF {
+	case KDFPBKDF2:
+		return pbkdf2.Key(sha256.New, passphrase, salt, int(params.Iterations), derivedKeySize)
+	case KDFScrypt:
+		return scrypt.Key([]byte(passphrase), salt, int(params.N), int(params.R), int(params.P), derivedKeySize)
+	default:
+		return argon2.IDKey([]byte(passphrase), salt, params.Time, params.Memory, params.Threads, derivedKeySize), nil
+	}
+}
+
+// AESpass encrypts with a key derived from a passphrase. A new salt is drawn
+// for every ciphertext and stored, together with the parameters, in its header.
+type AESpass struct {
+	aes        *AES
+	passphrase string
+	params     KDFParams
+}
+
+func (a *AES) RegisterPassphrase(passphrase string, params KDFParams) *AESpass {
+	return &AESpass{
+		aes:        a,
+		passphrase: passphrase,
+		params:     params,
+	}
+}
+
+func (p *AESpass) Encrypt(encryptStr string) (string, error) {
+	header, g, err := p.newHeader()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := g.Seal([]byte(encryptStr), header)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(append(header, sealed...)), nil
+}
+
+func (p *AESpass) Decrypt(decryptStr string) (string, error) {
+	raw, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < passHeaderSize {
+		return "", ErrInvalidPassphraseData
+	}
+
+	header := raw[:passHeaderSize]
+	g, err := p.parseHeader(header)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := g.Open(raw[passHeaderSize:], header)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// EncryptStream writes the passphrase header followed by an EncryptStream
+// payload, for large inputs such as backups.
+func (p *AESpass) EncryptStream(dst io.Writer, src io.Reader) error {
+	header, g, err := p.newHeader()
+	if err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	return g.EncryptStream(dst, src)
+}
+
+func (p *AESpass) DecryptStream(dst io.Writer, src io.Reader) error {
+	header := make([]byte, passHeaderSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrInvalidPassphraseData
+		}
+		return err
+	}
+
+	g, err := p.parseHeader(header)
+	if err != nil {
+		return err
+	}
+
+	return g.DecryptStream(dst, src)
+}
+
+func (p *AESpass) newHeader() ([]byte, *AESgcm, error) {
+	var buf bytes.Buffer
+	buf.WriteString(passMagic)
+	buf.WriteByte(byte(p.params.KDF))
+	buf.Write(p.params.marshal())
+
+	salt := make([]byte, passSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, nil, err
+	}
+	buf.Write(salt)
+
+	key, err := p.aes.DeriveKey(p.passphrase, salt, p.params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buf.Bytes(), &AESgcm{key: string(key)}, nil
+}
+
+func (p *AESpass) parseHeader(header []byte) (*AESgcm, error) {
+	if string(header[:4]) != passMagic {
+		return nil, ErrInvalidPassphraseData
+	}
+
+	params := unmarshalKDFParams(KDF(header[4]), header[5:5+passParamsSize])
+	salt := header[5+passParamsSize:]
+
+	key, err := p.aes.DeriveKey(p.passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AESgcm{key: string(key)}, nil
+}
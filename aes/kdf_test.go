@@ -0,0 +1,116 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+var testKDFParams = []KDFParams{
+	{KDF: KDFPBKDF2, Iterations: 1000},
+	{KDF: KDFScrypt, N: 1 << 10, R: 8, P: 1},
+	{KDF: KDFArgon2id, Time: 1, Memory: 1024, Threads: 1},
+}
+
+func TestPassphraseEncryptDecrypt(t *testing.T) {
+	a := New()
+
+	for _, params := range testKDFParams {
+		p := a.RegisterPassphrase("correct horse battery staple", params)
+
+		enc, err := p.Encrypt("backup")
+		if err != nil {
+			t.Fatalf("kdf %d: %v", params.KDF, err)
+		}
+
+		dec, err := p.Decrypt(enc)
+		if err != nil {
+			t.Fatalf("kdf %d: %v", params.KDF, err)
+		}
+
+		if dec != "backup" {
+			t.Errorf("kdf %d: expected backup, got %s", params.KDF, dec)
+		}
+
+		// Parameters travel with the ciphertext, so a reader configured
+		// differently still decrypts it.
+		reader := a.RegisterPassphrase("correct horse battery staple", DefaultPBKDF2Params())
+		if dec, err := reader.Decrypt(enc); err != nil || dec != "backup" {
+			t.Errorf("kdf %d: decrypt with other params: %q, %v", params.KDF, dec, err)
+		}
+
+		wrong := a.RegisterPassphrase("wrong", params)
+		if _, err := wrong.Decrypt(enc); err == nil {
+			t.Errorf("kdf %d: expected error with wrong passphrase", params.KDF)
+		}
+	}
+}
+
+func TestPassphraseStream(t *testing.T) {
+	p := New().RegisterPassphrase("secret", testKDFParams[0])
+	plain := bytes.Repeat([]byte("row\n"), 40000)
+
+	var enc bytes.Buffer
+	if err := p.EncryptStream(&enc, bytes.NewReader(plain)); err != nil {
+		t.Fatal(err)
+	}
+
+	var dec bytes.Buffer
+	if err := p.DecryptStream(&dec, &enc); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(plain, dec.Bytes()) {
+		t.Error("stream plaintext mismatch")
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	a := New()
+	salt := []byte("0123456789abcdef")
+
+	k1, err := a.DeriveKey("pass", salt, testKDFParams[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k2, _ := a.DeriveKey("pass", salt, testKDFParams[2])
+	if !bytes.Equal(k1, k2) || len(k1) != 32 {
+		t.Error("expected deterministic 32 byte key")
+	}
+
+	invalid := []KDFParams{
+		{},
+		{KDF: KDFPBKDF2},
+		{KDF: KDFScrypt, N: 1000, R: 8, P: 1},
+		{KDF: KDFScrypt, N: 1 << 10, R: maxScryptR + 1, P: 1},
+		{KDF: KDFScrypt, N: 1 << 10, R: 8, P: maxScryptP + 1},
+		{KDF: KDFScrypt, N: maxScryptN, R: 8, P: 1},
+		{KDF: KDFArgon2id, Time: 1, Memory: maxArgon2Memory + 1, Threads: 1},
+	}
+	for _, params := range invalid {
+		if _, err := a.DeriveKey("pass", salt, params); !errors.Is(err, ErrInvalidKDFParams) {
+			t.Errorf("params %+v: expected ErrInvalidKDFParams, got %v", params, err)
+		}
+	}
+}
+
+func TestPassphraseOversizedHeader(t *testing.T) {
+	p := New().RegisterPassphrase("secret", testKDFParams[1])
+
+	enc, err := p.Encrypt("backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := base64.URLEncoding.DecodeString(enc)
+
+	// Rewrite the scrypt parameters to N=2^22, r=2^20, which would need
+	// 512 TiB, and check decryption refuses them before deriving a key.
+	params := KDFParams{KDF: KDFScrypt, N: 1 << 22, R: 1 << 20, P: 1}
+	copy(raw[len(passMagic)+1:], params.marshal())
+
+	if _, err := p.Decrypt(base64.URLEncoding.EncodeToString(raw)); !errors.Is(err, ErrInvalidKDFParams) {
+		t.Errorf("expected ErrInvalidKDFParams, got %v", err)
+	}
+}
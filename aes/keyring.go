@@ -0,0 +1,189 @@
+package aes
+
+import (
+	caes "crypto/aes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Envelope layout: version(1) | algorithm(1) | len(keyID)(1) | keyID | payload.
+// The header is authenticated as additional data of the payload.
+const envelopeVersion byte = 1
+
+type Algorithm byte
+
+const (
+	AlgGCM Algorithm = iota + 1
+)
+
+var (
+	ErrNoPrimaryKey       = errors.New("aes: keyring has no primary key")
+	ErrUnknownKey         = errors.New("aes: unknown key id")
+	ErrInvalidEnvelope    = errors.New("aes: invalid envelope")
+	ErrUnsupportedVersion = errors.New("aes: unsupported envelope version")
+	ErrUnsupportedAlg     = errors.New("aes: unsupported algorithm")
+)
+
+// Keyring holds several identified keys so ciphertexts can be decrypted with
+// the key that produced them while new data is encrypted with the primary one.
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]*AESgcm
+	primary string
+}
+
+func (a *AES) NewKeyring() *Keyring {
+	return &Keyring{
+		keys: make(map[string]*AESgcm),
+	}
+}
+
+// AddKey registers key under id. The first key added becomes the primary.
+func (k *Keyring) AddKey(id, key string) error {
+	if len(id) == 0 || len(id) > 255 {
+		return fmt.Errorf("aes: key id must have 1 to 255 bytes, got %d", len(id))
+	}
+
+	if _, err := caes.NewCipher([]byte(key)); err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys[id] = &AESgcm{key: key}
+	if k.primary == "" {
+		k.primary = id
+	}
+	return nil
+}
+
+func (k *Keyring) RemoveKey(id string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	delete(k.keys, id)
+	if k.primary == id {
+		k.primary = ""
+	}
+}
+
+// SetPrimary selects the key used by Encrypt and Reencrypt.
+func (k *Keyring) SetPrimary(id string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.keys[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	k.primary = id
+	return nil
+}
+
+func (k *Keyring) Primary() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.primary
+}
+
+func (k *Keyring) Encrypt(encryptStr string) (string, error) {
+	k.mu.RLock()
+	id, g := k.primary, k.keys[k.primary]
+	k.mu.RUnlock()
+
+	if g == nil {
+		return "", ErrNoPrimaryKey
+	}
+
+	header := []byte{envelopeVersion, byte(AlgGCM), byte(len(id))}
+	header = append(header, id...)
+
+	sealed, err := g.Seal([]byte(encryptStr), header)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(append(header, sealed...)), nil
+}
+
+func (k *Keyring) Decrypt(decryptStr string) (string, error) {
+	raw, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	id, header, payload, err := parseEnvelope(raw)
+	if err != nil {
+		return "", err
+	}
+
+	k.mu.RLock()
+	g := k.keys[id]
+	k.mu.RUnlock()
+
+	if g == nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+
+	plain, err := g.Open(payload, header)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// KeyID returns the id of the key that produced the envelope.
+func (k *Keyring) KeyID(decryptStr string) (string, error) {
+	raw, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	id, _, _, err := parseEnvelope(raw)
+	return id, err
+}
+
+// Reencrypt migrates a ciphertext to the primary key. Envelopes already
+// encrypted with the primary key are returned unchanged.
+func (k *Keyring) Reencrypt(decryptStr string) (string, error) {
+	id, err := k.KeyID(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	if id == k.Primary() {
+		return decryptStr, nil
+	}
+
+	plain, err := k.Decrypt(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Encrypt(plain)
+}
+
+func parseEnvelope(raw []byte) (id string, header, payload []byte, err error) {
+	if len(raw) < 3 {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+
+	if raw[0] != envelopeVersion {
+		return "", nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, raw[0])
+	}
+
+	if Algorithm(raw[1]) != AlgGCM {
+		return "", nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedAlg, raw[1])
+	}
+
+	end := 3 + int(raw[2])
+	if raw[2] == 0 || len(raw) < end {
+		return "", nil, nil, ErrInvalidEnvelope
+	}
+
+	return string(raw[3:end]), raw[:end], raw[end:], nil
+}
@@ -0,0 +1,89 @@
+package aes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeyringRotation(t *testing.T) {
+	kr := New().NewKeyring()
+
+	if _, err := kr.Encrypt("x"); !errors.Is(err, ErrNoPrimaryKey) {
+		t.Fatalf("expected ErrNoPrimaryKey, got %v", err)
+	}
+
+	if err := kr.AddKey("2023", "IgkibX71IEf382PT"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kr.AddKey("bad", "short"); err == nil {
+		t.Error("expected error for invalid key size")
+	}
+
+	old, err := kr.Encrypt("123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kr.AddKey("2024", gcmKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kr.SetPrimary("2024"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := kr.SetPrimary("missing"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+
+	dec, err := kr.Decrypt(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dec != "123456" {
+		t.Errorf("expected 123456, got %s", dec)
+	}
+
+	migrated, err := kr.Reencrypt(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := kr.KeyID(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != "2024" {
+		t.Errorf("expected key id 2024, got %s", id)
+	}
+
+	again, err := kr.Reencrypt(migrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again != migrated {
+		t.Error("expected envelope on primary key to be returned unchanged")
+	}
+
+	kr.RemoveKey("2023")
+	if _, err := kr.Decrypt(old); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestKeyringInvalidEnvelope(t *testing.T) {
+	kr := New().NewKeyring()
+	_ = kr.AddKey("k1", gcmKey)
+
+	if _, err := kr.Decrypt("AQ=="); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("expected ErrInvalidEnvelope, got %v", err)
+	}
+
+	if _, err := kr.Decrypt("CQEBaw=="); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+package aes
+
+import (
+	caes "crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// AES-SIV as specified in RFC 5297.
+const sivSize = caes.BlockSize
+
+var ErrSIVAuth = errors.New("aes: siv authentication failed")
+
+// AESsiv is a deterministic AEAD: the same plaintext and associated data
+// always produce the same ciphertext, so encrypted columns can be looked up by
+// equality. It reveals when two values are equal, so use it only for fields
+// that must be searchable and pass the column name as associated data to keep
+// equal values in different columns apart.
+type AESsiv struct {
+	key string
+}
+
+// RegisterSIVKey takes a 32, 48 or 64 byte key: the first half authenticates
+// and the second half encrypts, giving AES-128, AES-192 or AES-256.
+func (a *AES) RegisterSIVKey(key string) *AESsiv {
+	return &AESsiv{
+		key: key,
+	}
+}
+
+func (s *AESsiv) Encrypt(encryptStr string) (string, error) {
+	return s.EncryptWithAD(encryptStr, nil)
+}
+
+func (s *AESsiv) Decrypt(decryptStr string) (string, error) {
+	return s.DecryptWithAD(decryptStr, nil)
+}
+
+func (s *AESsiv) EncryptWithAD(encryptStr string, additionalData []byte) (string, error) {
+	sealed, err := s.Seal([]byte(encryptStr), additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(sealed), nil
+}
+
+func (s *AESsiv) DecryptWithAD(decryptStr string, additionalData []byte) (string, error) {
+	sealed, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := s.Open(sealed, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// Seal returns V || C. Each additionalData element is authenticated as a
+// separate component of the RFC 5297 header vector; nil entries are skipped.
+func (s *AESsiv) Seal(plaintext []byte, additionalData ...[]byte) ([]byte, error) {
+	mac, ctr, err := s.ciphers()
+	if err != nil {
+		return nil, err
+	}
+
+	v := s2v(mac, nonNil(additionalData), plaintext)
+
+	out := make([]byte, sivSize+len(plaintext))
+	copy(out, v)
+	sivCTR(ctr, v, out[sivSize:], plaintext)
+	return out, nil
+}
+
+func (s *AESsiv) Open(sealed []byte, additionalData ...[]byte) ([]byte, error) {
+	if len(sealed) < sivSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	mac, ctr, err := s.ciphers()
+	if err != nil {
+		return nil, err
+	}
+
+	v := sealed[:sivSize]
+	plain := make([]byte, len(sealed)-sivSize)
+	sivCTR(ctr, v, plain, sealed[sivSize:])
+
+	if subtle.ConstantTimeCompare(v, s2v(mac, nonNil(additionalData), plain)) != 1 {
+		clear(plain)
+		return nil, ErrSIVAuth
+	}
+
+	return plain, nil
+}
+
+func (s *AESsiv) ciphers() (mac, ctr cipher.Block, err error) {
+	key := []byte(s.key)
+	if len(key) != 32 && len(key) != 48 && len(key) != 64 {
+		return nil, nil, fmt.Errorf("aes: invalid siv key size %d", len(key))
+	}
+
+	half := len(key) / 2
+	if mac, err = caes.NewCipher(key[:half]); err != nil {
+		return nil, nil, err
+	}
+
+	if ctr, err = caes.NewCipher(key[half:]); err != nil {
+		return nil, nil, err
+	}
+
+	return mac, ctr, nil
+}
+
+func nonNil(in [][]byte) [][]byte {
+	out := make([][]byte, 0, len(in))
+	for _, b := range in {
+		if b != nil {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func sivCTR(block cipher.Block, v, dst, src []byte) {
+	iv := make([]byte, sivSize)
+	copy(iv, v)
+	iv[8] &= 0x7f
+	iv[12] &= 0x7f
+	cipher.NewCTR(block, iv).XORKeyStream(dst, src)
+}
+
+func s2v(block cipher.Block, headers [][]byte, plaintext []byte) []byte {
+	d := cmac(block, make([]byte, sivSize))
+
+	for _, h := range headers {
+		dbl(d)
+		subtle.XORBytes(d, d, cmac(block, h))
+	}
+
+	var t []byte
+	if len(plaintext) >= sivSize {
+		t = make([]byte, len(plaintext))
+		copy(t, plaintext)
+		end := t[len(t)-sivSize:]
+		subtle.XORBytes(end, end, d)
+	} else {
+		dbl(d)
+		t = make([]byte, sivSize)
+		copy(t, plaintext)
+		t[len(plaintext)] = 0x80
+		subtle.XORBytes(t, t, d)
+	}
+
+	return cmac(block, t)
+}
+
+// cmac computes AES-CMAC (RFC 4493).
+func cmac(block cipher.Block, msg []byte) []byte {
+	k1 := make([]byte, sivSize)
+	block.Encrypt(k1, k1)
+	dbl(k1)
+
+	n := (len(msg) + sivSize - 1) / sivSize
+	last := make([]byte, sivSize)
+	if n > 0 && len(msg)%sivSize == 0 {
+		copy(last, msg[(n-1)*sivSize:])
+		subtle.XORBytes(last, last, k1)
+	} else {
+		k2 := append([]byte{}, k1...)
+		dbl(k2)
+		if n == 0 {
+			n = 1
+		}
+		rest := msg[(n-1)*sivSize:]
+		copy(last, rest)
+		last[len(rest)] = 0x80
+		subtle.XORBytes(last, last, k2)
+	}
+
+	x := make([]byte, sivSize)
+	for i := 0; i < n-1; i++ {
+		subtle.XORBytes(x, x, msg[i*sivSize:(i+1)*sivSize])
+		block.Encrypt(x, x)
+	}
+
+	subtle.XORBytes(x, x, last)
+	block.Encrypt(x, x)
+	return x
+}
+
+// dbl multiplies b by x in GF(2^128), in place.
+func dbl(b []byte) {
+	carry := b[0] >> 7
+	for i := 0; i < len(b)-1; i++ {
+		b[i] = b[i]<<1 | b[i+1]>>7
+	}
+	b[len(b)-1] = b[len(b)-1]<<1 ^ 0x87*carry
+}
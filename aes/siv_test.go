@@ -0,0 +1,112 @@
+package aes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func unhex(s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestSIVRFC5297Vectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		ad        [][]byte
+		plaintext string
+		expected  string
+	}{
+		{
+			name:      "A.1 deterministic",
+			key:       "fffefdfc fbfaf9f8 f7f6f5f4 f3f2f1f0 f0f1f2f3 f4f5f6f7 f8f9fafb fcfdfeff",
+			ad:        [][]byte{unhex("10111213 14151617 18191a1b 1c1d1e1f 20212223 24252627")},
+			plaintext: "11223344 55667788 99aabbcc ddee",
+			expected:  "85632d07 c6e8f37f 950acd32 0a2ecc93 40c02b96 90c4dc04 daef7f6a fe5c",
+		},
+		{
+			name: "A.2 nonce based",
+			key:  "7f7e7d7c 7b7a7978 77767574 73727170 40414243 44454647 48494a4b 4c4d4e4f",
+			ad: [][]byte{
+				unhex("00112233 44556677 8899aabb ccddeeff deaddada deaddada ffeeddcc bbaa9988 77665544 33221100"),
+				unhex("10203040 50607080 90a0"),
+				unhex("09f91102 9d74e35b d84156c5 635688c0"),
+			},
+			plaintext: "74686973 20697320 736f6d65 20706c61 696e7465 78742074 6f20656e 63727970 74207573 696e6720 5349562d 414553",
+			expected: "7bdb6e3b 432667eb 06f4d14b ff2fbd0f cb900f2f ddbe4043 26601965 c889bf17" +
+				"dba77ceb 094fa663 b7a3f748 ba8af829 ea64ad54 4a272e9c 485b62a3 fd5c0d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New().RegisterSIVKey(string(unhex(tt.key)))
+
+			sealed, err := s.Seal(unhex(tt.plaintext), tt.ad...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(sealed, unhex(tt.expected)) {
+				t.Errorf("expected %x, got %x", unhex(tt.expected), sealed)
+			}
+
+			plain, err := s.Open(sealed, tt.ad...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(plain, unhex(tt.plaintext)) {
+				t.Errorf("expected %s, got %x", tt.plaintext, plain)
+			}
+		})
+	}
+}
+
+func TestSIVDeterministic(t *testing.T) {
+	s := New().RegisterSIVKey(gcmKey)
+
+	a, err := s.EncryptWithAD("52998224725", []byte("customers.cpf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := s.EncryptWithAD("52998224725", []byte("customers.cpf"))
+	if a != b {
+		t.Error("expected equal ciphertexts for equal plaintexts")
+	}
+
+	c, _ := s.EncryptWithAD("52998224725", []byte("partners.cpf"))
+	if a == c {
+		t.Error("expected different ciphertexts for different associated data")
+	}
+
+	dec, err := s.DecryptWithAD(a, []byte("customers.cpf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dec != "52998224725" {
+		t.Errorf("expected 52998224725, got %s", dec)
+	}
+
+	if _, err := s.DecryptWithAD(a, []byte("partners.cpf")); !errors.Is(err, ErrSIVAuth) {
+		t.Errorf("expected ErrSIVAuth, got %v", err)
+	}
+
+	empty, _ := s.Encrypt("")
+	if dec, err := s.Decrypt(empty); err != nil || dec != "" {
+		t.Errorf("expected empty round trip, got %q, %v", dec, err)
+	}
+
+	if _, err := New().RegisterSIVKey(key).Encrypt("x"); err == nil {
+		t.Error("expected error for 16 byte key")
+	}
+}
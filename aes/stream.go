@@ -0,0 +1,227 @@
+package aes
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/thiagozs/go-xutils/files"
+)
+
+// Stream layout: magic(4) | chunkSize(4) | noncePrefix(7) followed by
+// sealed chunks. Each chunk nonce is noncePrefix | counter(4) | last(1) and
+// the header is authenticated with every chunk, so truncated, reordered or
+// spliced chunks fail to open.
+const (
+	streamMagic        = "XAS\x01"
+	streamPrefixSize   = 7
+	streamHeaderSize   = len(streamMagic) + 4 + streamPrefixSize
+	DefaultChunkSize   = 64 * 1024
+	maxStreamChunkSize = 16 * 1024 * 1024
+)
+
+var (
+	ErrInvalidStream   = errors.New("aes: invalid stream header")
+	ErrTruncatedStream = errors.New("aes: stream truncated")
+	ErrStreamAuth      = errors.New("aes: stream chunk authentication failed")
+	ErrStreamTooLarge  = errors.New("aes: stream exceeds maximum number of chunks")
+)
+
+// EncryptStream reads src until EOF and writes the authenticated stream to
+// dst, using a fixed amount of memory regardless of the input size.
+func (g *AESgcm) EncryptStream(dst io.Writer, src io.Reader) error {
+	return g.EncryptStreamChunk(dst, src, DefaultChunkSize)
+}
+
+func (g *AESgcm) EncryptStreamChunk(dst io.Writer, src io.Reader, chunkSize int) error {
+	if chunkSize <= 0 || chunkSize > maxStreamChunkSize {
+		return fmt.Errorf("aes: chunk size must be between 1 and %d", maxStreamChunkSize)
+	}
+
+	aead, err := g.aead()
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, streamHeaderSize)
+	copy(header, streamMagic)
+	binary.BigEndian.PutUint32(header[4:8], uint32(chunkSize))
+	if _, err := io.ReadFull(rand.Reader, header[8:]); err != nil {
+		return err
+	}
+
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	br := bufio.NewReaderSize(src, chunkSize)
+	buf := make([]byte, chunkSize, chunkSize+aead.Overhead())
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, header[8:])
+
+	for counter := uint64(0); ; counter++ {
+		if counter > 0xFFFFFFFF {
+			return ErrStreamTooLarge
+		}
+
+		n, err := io.ReadFull(br, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		last := n < chunkSize
+		if !last {
+			if _, perr := br.Peek(1); perr == io.EOF {
+				last = true
+			} else if perr != nil {
+				return perr
+			}
+		}
+
+		streamNonce(nonce, uint32(counter), last)
+		if _, err := dst.Write(aead.Seal(buf[:0], nonce, buf[:n], header)); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// DecryptStream verifies and decrypts a stream written by EncryptStream.
+// Plaintext is written chunk by chunk as each one is authenticated, so on
+// error dst may already hold a prefix of the data.
+func (g *AESgcm) DecryptStream(dst io.Writer, src io.Reader) error {
+	aead, err := g.aead()
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(src, header); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrInvalidStream
+		}
+		return err
+	}
+
+	if string(header[:4]) != streamMagic {
+		return ErrInvalidStream
+	}
+
+	chunkSize := int(binary.BigEndian.Uint32(header[4:8]))
+	if chunkSize <= 0 || chunkSize > maxStreamChunkSize {
+		return ErrInvalidStream
+	}
+
+	sealedSize := chunkSize + aead.Overhead()
+	br := bufio.NewReaderSize(src, sealedSize)
+	buf := make([]byte, sealedSize)
+	out := make([]byte, 0, chunkSize)
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, header[8:])
+
+	for counter := uint64(0); ; counter++ {
+		if counter > 0xFFFFFFFF {
+			return ErrStreamTooLarge
+		}
+
+		n, err := io.ReadFull(br, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		if n < aead.Overhead() {
+			return ErrTruncatedStream
+		}
+
+		last := n < sealedSize
+		if !last {
+			if _, perr := br.Peek(1); perr == io.EOF {
+				last = true
+			} else if perr != nil {
+				return perr
+			}
+		}
+
+		streamNonce(nonce, uint32(counter), last)
+		plain, err := aead.Open(out[:0], nonce, buf[:n], header)
+		if err != nil {
+			if last {
+				// A chunk sealed as intermediate but found at the end means
+				// the stream was cut on a chunk boundary.
+				streamNonce(nonce, uint32(counter), false)
+				if _, ierr := aead.Open(out[:0], nonce, buf[:n], header); ierr == nil {
+					return ErrTruncatedStream
+				}
+			}
+			return fmt.Errorf("%w: chunk %d", ErrStreamAuth, counter)
+		}
+
+		if _, err := dst.Write(plain); err != nil {
+			return err
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+// EncryptFile encrypts the file at srcPath into dstPath.
+func (g *AESgcm) EncryptFile(dstPath, srcPath string) error {
+	return g.streamFile(dstPath, srcPath, g.EncryptStream)
+}
+
+// DecryptFile decrypts the file at srcPath into dstPath. The output is
+// written to a temporary file and only moved into place once the whole
+// stream is authenticated.
+func (g *AESgcm) DecryptFile(dstPath, srcPath string) error {
+	return g.streamFile(dstPath, srcPath, g.DecryptStream)
+}
+
+func (g *AESgcm) streamFile(dstPath, srcPath string, fn func(io.Writer, io.Reader) error) error {
+	fs := files.New()
+	if !fs.IsFile(srcPath) {
+		return fmt.Errorf("aes: source is not a file: %s", srcPath)
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), "."+filepath.Base(dstPath)+".*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if err := fn(tmp, src); err != nil {
+		tmp.Close()
+		_ = fs.RemoveFile(tmpPath)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = fs.RemoveFile(tmpPath)
+		return err
+	}
+
+	return fs.RenameFile(tmpPath, dstPath)
+}
+
+func streamNonce(nonce []byte, counter uint32, last bool) {
+	binary.BigEndian.PutUint32(nonce[streamPrefixSize:], counter)
+	nonce[len(nonce)-1] = 0
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+}
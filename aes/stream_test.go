@@ -0,0 +1,125 @@
+package aes
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStreamRoundTrip(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+
+	for _, size := range []int{0, 1, 100, 256, 1000} {
+		plain := make([]byte, size)
+		_, _ = rand.Read(plain)
+
+		var enc bytes.Buffer
+		if err := g.EncryptStreamChunk(&enc, bytes.NewReader(plain), 256); err != nil {
+			t.Fatal(err)
+		}
+
+		var dec bytes.Buffer
+		if err := g.DecryptStream(&dec, &enc); err != nil {
+			t.Fatalf("size %d: %v", size, err)
+		}
+
+		if !bytes.Equal(plain, dec.Bytes()) {
+			t.Errorf("size %d: plaintext mismatch", size)
+		}
+	}
+}
+
+func TestStreamTamper(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+	chunk := 64
+	sealed := chunk + 16
+
+	plain := make([]byte, chunk*3)
+	var enc bytes.Buffer
+	if err := g.EncryptStreamChunk(&enc, bytes.NewReader(plain), chunk); err != nil {
+		t.Fatal(err)
+	}
+	data := enc.Bytes()
+	body := data[streamHeaderSize:]
+
+	t.Run("truncated at chunk boundary", func(t *testing.T) {
+		cut := data[:streamHeaderSize+2*sealed]
+		err := g.DecryptStream(&bytes.Buffer{}, bytes.NewReader(cut))
+		if !errors.Is(err, ErrTruncatedStream) {
+			t.Errorf("expected ErrTruncatedStream, got %v", err)
+		}
+	})
+
+	t.Run("header only", func(t *testing.T) {
+		err := g.DecryptStream(&bytes.Buffer{}, bytes.NewReader(data[:streamHeaderSize]))
+		if !errors.Is(err, ErrTruncatedStream) {
+			t.Errorf("expected ErrTruncatedStream, got %v", err)
+		}
+	})
+
+	t.Run("reordered", func(t *testing.T) {
+		swapped := append([]byte{}, data[:streamHeaderSize]...)
+		swapped = append(swapped, body[sealed:2*sealed]...)
+		swapped = append(swapped, body[:sealed]...)
+		swapped = append(swapped, body[2*sealed:]...)
+		err := g.DecryptStream(&bytes.Buffer{}, bytes.NewReader(swapped))
+		if !errors.Is(err, ErrStreamAuth) {
+			t.Errorf("expected ErrStreamAuth, got %v", err)
+		}
+	})
+
+	t.Run("bad magic", func(t *testing.T) {
+		err := g.DecryptStream(&bytes.Buffer{}, bytes.NewReader([]byte("nope")))
+		if !errors.Is(err, ErrInvalidStream) {
+			t.Errorf("expected ErrInvalidStream, got %v", err)
+		}
+	})
+}
+
+func TestEncryptDecryptFile(t *testing.T) {
+	g := New().RegisterGCMKey(gcmKey)
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "export.csv")
+	enc := filepath.Join(dir, "export.csv.enc")
+	dec := filepath.Join(dir, "export.out.csv")
+
+	plain := bytes.Repeat([]byte("a,b,c\n"), 50000)
+	if err := os.WriteFile(src, plain, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.EncryptFile(enc, src); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.DecryptFile(dec, enc); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(dec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(plain, got) {
+		t.Error("decrypted file mismatch")
+	}
+
+	wrong := New().RegisterGCMKey("IgkibX71IEf382PT")
+	bad := filepath.Join(dir, "bad.csv")
+	if err := wrong.DecryptFile(bad, enc); err == nil {
+		t.Error("expected error decrypting with wrong key")
+	}
+
+	if _, err := os.Stat(bad); !os.IsNotExist(err) {
+		t.Error("expected no output file after failed decryption")
+	}
+
+	if err := g.EncryptFile(enc, filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing source")
+	}
+}
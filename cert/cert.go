@@ -0,0 +1,271 @@
+package cert
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/thiagozs/go-xutils/ip"
+)
+
+const (
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypeCSR         = "CERTIFICATE REQUEST"
+
+	DefaultCAValidity   = 10 * 365 * 24 * time.Hour
+	DefaultLeafValidity = 365 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPem = errors.New("cert: failed to parse PEM block containing the certificate")
+	ErrInvalidIP  = errors.New("cert: invalid IP address")
+	ErrNotCA      = errors.New("cert: issuer is not a CA")
+)
+
+type Cert struct{}
+
+func New() *Cert {
+	return &Cert{}
+}
+
+// Options describes the subject and lifetime of a certificate or CSR.
+type Options struct {
+	CommonName   string
+	Organization []string
+	DNSNames     []string
+	// IPAddresses are validated with the ip package.
+	IPAddresses []string
+	// NotBefore defaults to now and ValidFor to DefaultCAValidity or
+	// DefaultLeafValidity.
+	NotBefore time.Time
+	ValidFor  time.Duration
+	// ExtKeyUsage defaults to server and client authentication for leaves.
+	ExtKeyUsage []x509.ExtKeyUsage
+}
+
+// CreateCA creates a self-signed CA certificate. key is usually an
+// *rsa.PrivateKey from rsa.RSAPem, but any crypto.Signer works.
+func (c *Cert) CreateCA(key crypto.Signer, opts Options) (*x509.Certificate, error) {
+	tmpl, err := template(opts, DefaultCAValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	return create(tmpl, tmpl, key.Public(), key)
+}
+
+// IssueCertificate issues a leaf certificate for pub signed by the CA.
+func (c *Cert) IssueCertificate(ca *x509.Certificate, caKey crypto.Signer, pub crypto.PublicKey, opts Options) (*x509.Certificate, error) {
+	if !ca.IsCA {
+		return nil, ErrNotCA
+	}
+
+	tmpl, err := template(opts, DefaultLeafValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.BasicConstraintsValid = true
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	if _, ok := pub.(*rsa.PublicKey); ok {
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	tmpl.ExtKeyUsage = opts.ExtKeyUsage
+	if len(tmpl.ExtKeyUsage) == 0 {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+
+	// Never let a leaf outlive its issuer.
+	if tmpl.NotAfter.After(ca.NotAfter) {
+		tmpl.NotAfter = ca.NotAfter
+	}
+
+	return create(tmpl, ca, pub, caKey)
+}
+
+// IssueFromCSR issues a leaf certificate for a verified CSR, keeping its
+// subject and SANs. Only NotBefore, ValidFor and ExtKeyUsage are read from opts.
+func (c *Cert) IssueFromCSR(ca *x509.Certificate, caKey crypto.Signer, csr *x509.CertificateRequest, opts Options) (*x509.Certificate, error) {
+	if err := csr.CheckSignature(); err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, len(csr.IPAddresses))
+	for i, addr := range csr.IPAddresses {
+		ips[i] = addr.String()
+	}
+
+	opts.CommonName = csr.Subject.CommonName
+	opts.Organization = csr.Subject.Organization
+	opts.DNSNames = csr.DNSNames
+	opts.IPAddresses = ips
+
+	return c.IssueCertificate(ca, caKey, csr.PublicKey, opts)
+}
+
+// CreateCSR builds a PEM encoded certificate signing request.
+func (c *Cert) CreateCSR(key crypto.Signer, opts Options) (string, error) {
+	ips, err := parseIPs(opts.IPAddresses)
+	if err != nil {
+		return "", err
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:    opts.DNSNames,
+		IPAddresses: ips,
+	}, key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeCSR, Bytes: der})), nil
+}
+
+func (c *Cert) ParseCSRFromPem(str string) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode([]byte(str))
+	if block == nil || block.Type != pemTypeCSR {
+		return nil, ErrInvalidPem
+	}
+
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+func (c *Cert) ExportCertificateAsPem(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: cert.Raw}))
+}
+
+// ParseCertificateFromPem parses the first certificate in str.
+func (c *Cert) ParseCertificateFromPem(str string) (*x509.Certificate, error) {
+	certs, err := c.ParseCertificatesFromPem(str)
+	if err != nil {
+		return nil, err
+	}
+	return certs[0], nil
+}
+
+// ParseCertificatesFromPem parses every certificate of a PEM bundle, such
+// as a leaf followed by its chain.
+func (c *Cert) ParseCertificatesFromPem(str string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(str)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != pemTypeCertificate {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrInvalidPem
+	}
+	return certs, nil
+}
+
+// Expiry summarizes the validity window of a certificate.
+type Expiry struct {
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+	ExpiresIn time.Duration
+	Expired   bool
+}
+
+func (c *Cert) Expiry(cert *x509.Certificate) Expiry {
+	return expiryAt(cert, time.Now())
+}
+
+// ExpiresWithin reports whether cert is expired or expires within d, for
+// renewal checks.
+func (c *Cert) ExpiresWithin(cert *x509.Certificate, d time.Duration) bool {
+	return c.Expiry(cert).ExpiresIn < d
+}
+
+func expiryAt(cert *x509.Certificate, now time.Time) Expiry {
+	in := cert.NotAfter.Sub(now)
+	return Expiry{
+		Subject:   cert.Subject.String(),
+		NotBefore: cert.NotBefore,
+		NotAfter:  cert.NotAfter,
+		ExpiresIn: in,
+		Expired:   in <= 0,
+	}
+}
+
+func template(opts Options, defaultValidity time.Duration) (*x509.Certificate, error) {
+	ips, err := parseIPs(opts.IPAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	notBefore := opts.NotBefore
+	if notBefore.IsZero() {
+		// Backdate slightly to tolerate clock skew between peers.
+		notBefore = time.Now().Add(-5 * time.Minute)
+	}
+
+	validFor := opts.ValidFor
+	if validFor == 0 {
+		validFor = defaultValidity
+	}
+
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: opts.CommonName, Organization: opts.Organization},
+		DNSNames:     opts.DNSNames,
+		IPAddresses:  ips,
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(validFor),
+	}, nil
+}
+
+func create(tmpl, parent *x509.Certificate, pub crypto.PublicKey, key crypto.Signer) (*x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+func parseIPs(addrs []string) ([]net.IP, error) {
+	v := ip.New()
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		parsed := net.ParseIP(addr)
+		if !v.IP(addr) || parsed == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIP, addr)
+		}
+		ips = append(ips, parsed)
+	}
+	return ips, nil
+}
@@ -0,0 +1,137 @@
+package cert
+
+import (
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thiagozs/go-xutils/ec"
+	xrsa "github.com/thiagozs/go-xutils/rsa"
+)
+
+var testKeys = xrsa.NewKeyPool()
+
+func TestCAAndLeaf(t *testing.T) {
+	c := New()
+
+	caKey, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+	leafKey, err := testKeys.Key(2048, 1)
+	require.NoError(t, err)
+
+	ca, err := c.CreateCA(caKey, Options{CommonName: "xutils dev CA", Organization: []string{"xutils"}})
+	require.NoError(t, err)
+	assert.True(t, ca.IsCA)
+
+	leaf, err := c.IssueCertificate(ca, caKey, &leafKey.PublicKey, Options{
+		CommonName:  "payments",
+		DNSNames:    []string{"payments.internal", "localhost"},
+		IPAddresses: []string{"127.0.0.1", "::1"},
+		ValidFor:    24 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.False(t, leaf.IsCA)
+	assert.Len(t, leaf.IPAddresses, 2)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	for _, name := range []string{"payments.internal", "127.0.0.1", "::1"} {
+		_, err = leaf.Verify(x509.VerifyOptions{DNSName: name, Roots: pool})
+		assert.NoError(t, err, name)
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{DNSName: "other.internal", Roots: pool})
+	assert.Error(t, err)
+
+	_, err = c.IssueCertificate(leaf, leafKey, &leafKey.PublicKey, Options{})
+	assert.ErrorIs(t, err, ErrNotCA)
+
+	bundle := c.ExportCertificateAsPem(leaf) + c.ExportCertificateAsPem(ca)
+	assert.True(t, strings.HasPrefix(bundle, "-----BEGIN CERTIFICATE-----"))
+
+	certs, err := c.ParseCertificatesFromPem(bundle)
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.True(t, certs[0].Equal(leaf))
+	assert.True(t, certs[1].Equal(ca))
+
+	first, err := c.ParseCertificateFromPem(bundle)
+	require.NoError(t, err)
+	assert.True(t, first.Equal(leaf))
+}
+
+func TestCSR(t *testing.T) {
+	c := New()
+
+	caKey, _, err := ec.NewPem().ECDSAGenKeyPair(nil)
+	require.NoError(t, err)
+	ca, err := c.CreateCA(caKey, Options{CommonName: "ec CA"})
+	require.NoError(t, err)
+
+	key, err := testKeys.Key(2048, 1)
+	require.NoError(t, err)
+
+	csrPem, err := c.CreateCSR(key, Options{
+		CommonName:  "worker",
+		DNSNames:    []string{"worker.internal"},
+		IPAddresses: []string{"10.0.0.7"},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(csrPem, "-----BEGIN CERTIFICATE REQUEST-----"))
+
+	csr, err := c.ParseCSRFromPem(csrPem)
+	require.NoError(t, err)
+	assert.Equal(t, "worker", csr.Subject.CommonName)
+
+	leaf, err := c.IssueFromCSR(ca, caKey, csr, Options{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"worker.internal"}, leaf.DNSNames)
+	assert.Equal(t, "10.0.0.7", leaf.IPAddresses[0].String())
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, leaf.ExtKeyUsage)
+	assert.False(t, leaf.NotAfter.After(ca.NotAfter))
+}
+
+func TestInvalidIP(t *testing.T) {
+	c := New()
+	key, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+
+	for _, addr := range []string{"256.0.0.1", "example.com", "01.2.3.4"} {
+		_, err = c.CreateCA(key, Options{IPAddresses: []string{addr}})
+		assert.ErrorIs(t, err, ErrInvalidIP, addr)
+
+		_, err = c.CreateCSR(key, Options{IPAddresses: []string{addr}})
+		assert.ErrorIs(t, err, ErrInvalidIP, addr)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	c := New()
+	key, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	ca, err := c.CreateCA(key, Options{CommonName: "short", NotBefore: start, ValidFor: time.Hour})
+	require.NoError(t, err)
+
+	exp := c.Expiry(ca)
+	assert.True(t, exp.Expired)
+	assert.Equal(t, "CN=short", exp.Subject)
+	assert.Equal(t, start.Add(time.Hour).UTC(), exp.NotAfter.UTC())
+
+	live := expiryAt(ca, start.Add(30*time.Minute))
+	assert.False(t, live.Expired)
+	assert.Equal(t, 30*time.Minute, live.ExpiresIn)
+
+	fresh, err := c.CreateCA(key, Options{ValidFor: 48 * time.Hour})
+	require.NoError(t, err)
+	assert.False(t, c.ExpiresWithin(fresh, 24*time.Hour))
+	assert.True(t, c.ExpiresWithin(fresh, 72*time.Hour))
+
+	_, err = c.ParseCertificateFromPem("garbage")
+	assert.ErrorIs(t, err, ErrInvalidPem)
+}
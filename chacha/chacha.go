@@ -0,0 +1,110 @@
+package chacha
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var ErrCiphertextTooShort = errors.New("chacha: ciphertext too short")
+
+type ChaCha struct{}
+
+func New() *ChaCha {
+	return &ChaCha{}
+}
+
+// ChaChaki encrypts with ChaCha20-Poly1305 or, when registered with
+// RegisterXKey, XChaCha20-Poly1305. A random nonce is prepended to every
+// ciphertext; prefer the X variant when encrypting many messages with the
+// same key, as its 24 byte nonce makes random collisions negligible.
+type ChaChaki struct {
+	key      string
+	extended bool
+}
+
+// RegisterKey takes a 32 byte key for ChaCha20-Poly1305.
+func (c *ChaCha) RegisterKey(key string) *ChaChaki {
+	return &ChaChaki{
+		key: key,
+	}
+}
+
+// RegisterXKey takes a 32 byte key for XChaCha20-Poly1305.
+func (c *ChaCha) RegisterXKey(key string) *ChaChaki {
+	return &ChaChaki{
+		key:      key,
+		extended: true,
+	}
+}
+
+func (c *ChaChaki) Encrypt(encryptStr string) (string, error) {
+	return c.EncryptWithAD(encryptStr, nil)
+}
+
+func (c *ChaChaki) Decrypt(decryptStr string) (string, error) {
+	return c.DecryptWithAD(decryptStr, nil)
+}
+
+func (c *ChaChaki) EncryptWithAD(encryptStr string, additionalData []byte) (string, error) {
+	sealed, err := c.Seal([]byte(encryptStr), additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(sealed), nil
+}
+
+func (c *ChaChaki) DecryptWithAD(decryptStr string, additionalData []byte) (string, error) {
+	sealed, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := c.Open(sealed, additionalData)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// Seal returns nonce || ciphertext || tag as raw bytes.
+func (c *ChaChaki) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (c *ChaChaki) Open(sealed, additionalData []byte) ([]byte, error) {
+	aead, err := c.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrCiphertextTooShort
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func (c *ChaChaki) aead() (cipher.AEAD, error) {
+	if c.extended {
+		return chacha20poly1305.NewX([]byte(c.key))
+	}
+	return chacha20poly1305.New([]byte(c.key))
+}
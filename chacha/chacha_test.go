@@ -0,0 +1,72 @@
+package chacha
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+const key = "IgkibX71IEf382PTIgkibX71IEf382PT"
+
+func TestEncryptDecrypt(t *testing.T) {
+	c := New()
+
+	for name, ki := range map[string]*ChaChaki{
+		"chacha20-poly1305":  c.RegisterKey(key),
+		"xchacha20-poly1305": c.RegisterXKey(key),
+	} {
+		t.Run(name, func(t *testing.T) {
+			enc, err := ki.Encrypt("123456")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			again, _ := ki.Encrypt("123456")
+			if enc == again {
+				t.Error("expected random nonce per message")
+			}
+
+			dec, err := ki.Decrypt(enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dec != "123456" {
+				t.Errorf("expected 123456, got %s", dec)
+			}
+
+			raw, _ := base64.URLEncoding.DecodeString(enc)
+			raw[len(raw)-1] ^= 1
+			if _, err := ki.Decrypt(base64.URLEncoding.EncodeToString(raw)); err == nil {
+				t.Error("expected error for tampered ciphertext")
+			}
+
+			if _, err := ki.Open(raw[:8], nil); !errors.Is(err, ErrCiphertextTooShort) {
+				t.Errorf("expected ErrCiphertextTooShort, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAdditionalData(t *testing.T) {
+	ki := New().RegisterXKey(key)
+
+	enc, err := ki.EncryptWithAD("payload", []byte("device-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ki.DecryptWithAD(enc, []byte("device-2")); err == nil {
+		t.Error("expected error with wrong additional data")
+	}
+
+	if dec, err := ki.DecryptWithAD(enc, []byte("device-1")); err != nil || dec != "payload" {
+		t.Errorf("expected payload, got %q, %v", dec, err)
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	if _, err := New().RegisterKey("short").Encrypt("x"); err == nil {
+		t.Error("expected error for invalid key size")
+	}
+}
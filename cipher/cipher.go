@@ -0,0 +1,64 @@
+package cipher
+
+import (
+	"fmt"
+
+	"github.com/thiagozs/go-xutils/aes"
+	"github.com/thiagozs/go-xutils/chacha"
+)
+
+// Cipher is the common shape of the string ciphers in xutils: plaintext in,
+// URL-safe base64 out.
+type Cipher interface {
+	Encrypt(string) (string, error)
+	Decrypt(string) (string, error)
+}
+
+// AEAD is a Cipher that can also authenticate associated data.
+type AEAD interface {
+	Cipher
+	EncryptWithAD(string, []byte) (string, error)
+	DecryptWithAD(string, []byte) (string, error)
+}
+
+var (
+	_ Cipher = (*aes.AESki)(nil)
+	_ Cipher = (*aes.Keyring)(nil)
+	_ Cipher = (*aes.AESpass)(nil)
+	_ AEAD   = (*aes.AESgcm)(nil)
+	_ AEAD   = (*aes.AESsiv)(nil)
+	_ AEAD   = (*chacha.ChaChaki)(nil)
+)
+
+type Algorithm string
+
+const (
+	AESGCM            Algorithm = "aes-gcm"
+	AESSIV            Algorithm = "aes-siv"
+	ChaCha20Poly1305  Algorithm = "chacha20-poly1305"
+	XChaCha20Poly1305 Algorithm = "xchacha20-poly1305"
+)
+
+// New returns the AEAD for alg keyed with key, so callers can switch
+// algorithms through configuration. The key size is checked up front.
+func New(alg Algorithm, key string) (AEAD, error) {
+	var c AEAD
+	switch alg {
+	case AESGCM:
+		c = aes.New().RegisterGCMKey(key)
+	case AESSIV:
+		c = aes.New().RegisterSIVKey(key)
+	case ChaCha20Poly1305:
+		c = chacha.New().RegisterKey(key)
+	case XChaCha20Poly1305:
+		c = chacha.New().RegisterXKey(key)
+	default:
+		return nil, fmt.Errorf("cipher: unknown algorithm %q", alg)
+	}
+
+	if _, err := c.Encrypt(""); err != nil {
+		return nil, fmt.Errorf("cipher: %s: %w", alg, err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,48 @@
+package cipher
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		alg Algorithm
+		key string
+	}{
+		{AESGCM, "IgkibX71IEf382PT"},
+		{AESSIV, "IgkibX71IEf382PTIgkibX71IEf382PT"},
+		{ChaCha20Poly1305, "IgkibX71IEf382PTIgkibX71IEf382PT"},
+		{XChaCha20Poly1305, "IgkibX71IEf382PTIgkibX71IEf382PT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.alg), func(t *testing.T) {
+			c, err := New(tt.alg, tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			enc, err := c.EncryptWithAD("123456", []byte("ctx"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dec, err := c.DecryptWithAD(enc, []byte("ctx"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dec != "123456" {
+				t.Errorf("expected 123456, got %s", dec)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New("rot13", "key"); err == nil {
+		t.Error("expected error for unknown algorithm")
+	}
+
+	if _, err := New(ChaCha20Poly1305, "short"); err == nil {
+		t.Error("expected error for invalid key")
+	}
+}
@@ -2,24 +2,42 @@ package cnpj
 
 import (
 	"regexp"
-	"strconv"
 	"strings"
 
 	"github.com/thiagozs/go-xutils/randutil"
 )
 
+// Format selects the kind of CNPJ Generate produces.
+type Format int
+
+const (
+	// FormatNumeric is the traditional 14-digit CNPJ.
+	FormatNumeric Format = iota
+	// FormatAlphanumeric allows letters A-Z in the first 12 positions, as
+	// introduced by Receita Federal in 2026. Check digits stay numeric.
+	FormatAlphanumeric
+)
+
+const alphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
 type CNPJ struct{}
 
 func New() *CNPJ {
 	return &CNPJ{}
 }
 
-// GenerateCNPJ generates a random CNPJ
-func (c *CNPJ) Generate() string {
-	// Generate the first 12 random digits of the CNPJ
+// GenerateCNPJ generates a random CNPJ, numeric unless FormatAlphanumeric is
+// given
+func (c *CNPJ) Generate(format ...Format) string {
+	charset := alphanumeric[:10]
+	if len(format) > 0 && format[0] == FormatAlphanumeric {
+		charset = alphanumeric
+	}
+
+	// Generate the first 12 random characters of the CNPJ
 	numbers := make([]int, 12)
 	for i := range numbers {
-		numbers[i] = randutil.Global.Intn(10)
+		numbers[i] = c.value(charset[randutil.Global.Intn(len(charset))])
 	}
 
 	// Calculate the first check digit
@@ -28,14 +46,20 @@ func (c *CNPJ) Generate() string {
 	// Calculate the second check digit
 	numbers = append(numbers, c.calculateCheckDigit(numbers))
 
-	// Convert the CNPJ numbers to a string using strings.Builder
+	// Convert the CNPJ values back to characters using strings.Builder
 	var b strings.Builder
 	for _, number := range numbers {
-		b.WriteString(strconv.Itoa(number))
+		b.WriteByte(byte(number + '0'))
 	}
 	return b.String()
 }
 
+// value maps a CNPJ character to its ASCII code minus 48, so digits keep
+// their value and letters A-Z become 17-42.
+func (c *CNPJ) value(char byte) int {
+	return int(char) - '0'
+}
+
 func (c *CNPJ) calculateCheckDigit(numbers []int) int {
 	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
 
@@ -51,34 +75,21 @@ func (c *CNPJ) calculateCheckDigit(numbers []int) int {
 	return 11 - remainder
 }
 
-// IsValidCNPJ validates a CNPJ
+// IsValidCNPJ validates a numeric or alphanumeric CNPJ
 func (c *CNPJ) IsValid(cnpj string) bool {
 	cnpj = c.TrimCNPJ(cnpj)
 
-	if len(cnpj) != 14 {
+	if !reCNPJ.MatchString(cnpj) {
 		return false
 	}
 
-	sum := 0
-	for _, digit := range cnpj[:12] {
-		num, err := strconv.Atoi(string(digit))
-		if err != nil {
-			return false
-		}
-		sum += num
-	}
-
-	if sum == 0 {
+	if strings.Trim(cnpj[:12], "0") == "" {
 		return false
 	}
 
 	numbers := make([]int, 14)
-	for i, digit := range cnpj {
-		num, err := strconv.Atoi(string(digit))
-		if err != nil {
-			return false
-		}
-		numbers[i] = num
+	for i := range cnpj {
+		numbers[i] = c.value(cnpj[i])
 	}
 
 	// Validate the first and second check digits
@@ -88,9 +99,18 @@ func (c *CNPJ) IsValid(cnpj string) bool {
 	return c.calculateCheckDigit(numbers[:13]) == numbers[13]
 }
 
-// TrimCNPJ trims CNPJ
+// IsAlphanumeric reports whether a valid CNPJ uses the alphanumeric format
+func (c *CNPJ) IsAlphanumeric(cnpj string) bool {
+	return c.IsValid(cnpj) && strings.ContainsAny(c.TrimCNPJ(cnpj)[:12], alphanumeric[10:])
+}
+
+// TrimCNPJ trims CNPJ, keeping digits and letters and converting letters to
+// upper case
 func (c *CNPJ) TrimCNPJ(cnpj string) string {
-	return reNonDigits.ReplaceAllString(cnpj, "")
+	return reNonAlphanumeric.ReplaceAllString(strings.ToUpper(cnpj), "")
 }
 
-var reNonDigits = regexp.MustCompile(`\D`)
+var (
+	reNonAlphanumeric = regexp.MustCompile(`[^0-9A-Z]`)
+	reCNPJ            = regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+)
@@ -1,6 +1,7 @@
 package cnpj
 
 import (
+	"regexp"
 	"testing"
 )
 
@@ -27,6 +28,53 @@ func TestCNPJ(t *testing.T) {
 		}
 	})
 
+	t.Run("Validate alphanumeric CNPJ", func(t *testing.T) {
+		tests := []struct {
+			cnpj     string
+			expected bool
+		}{
+			{"12.ABC.345/01DE-35", true},
+			{"12abc34501de35", true},
+			{"12ABC34501DE36", false},
+			{"12ABC34501DE3A", false},
+			{"12ABC34501DE", false},
+			{"00000000000000", false},
+		}
+
+		for _, test := range tests {
+			if actual := c.IsValid(test.cnpj); actual != test.expected {
+				t.Errorf("IsValid(%s): expected %v, got %v", test.cnpj, test.expected, actual)
+			}
+		}
+
+		if !c.IsAlphanumeric("12.ABC.345/01DE-35") || c.IsAlphanumeric("15757747000166") {
+			t.Errorf("IsAlphanumeric gave the wrong answer")
+		}
+	})
+
+	t.Run("Generate CNPJ formats", func(t *testing.T) {
+		numeric := regexp.MustCompile(`^[0-9]{14}$`)
+		alphanumeric := regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+
+		sawLetter := false
+		for i := 0; i < 100; i++ {
+			n := c.Generate(FormatNumeric)
+			if !numeric.MatchString(n) || !c.IsValid(n) {
+				t.Fatalf("generated invalid numeric CNPJ %s", n)
+			}
+
+			a := c.Generate(FormatAlphanumeric)
+			if !alphanumeric.MatchString(a) || !c.IsValid(a) {
+				t.Fatalf("generated invalid alphanumeric CNPJ %s", a)
+			}
+			sawLetter = sawLetter || c.IsAlphanumeric(a)
+		}
+
+		if !sawLetter {
+			t.Errorf("expected alphanumeric CNPJs to contain letters")
+		}
+	})
+
 	t.Run("Trim CNPJ", func(t *testing.T) {
 		type testCase struct {
 			cnpj     string
@@ -39,7 +87,8 @@ func TestCNPJ(t *testing.T) {
 			{" 11...444.777///0001-61", "11444777000161"},
 			{" 11.444.777/0001-61 ", "11444777000161"},
 			{"11.444.777/0001---61", "11444777000161"},
-			{"11.444.777/0001-61adas", "11444777000161"},
+			{"11.444.777/0001-61adas", "11444777000161ADAS"},
+			{"12.abc.345/01de-35", "12ABC34501DE35"},
 			{"11+444+777/0001-61", "11444777000161"},
 			{"11.444.777/\\\\0001-61", "11444777000161"},
 			{"11444777000161", "11444777000161"},
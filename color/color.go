@@ -0,0 +1,320 @@
+package color
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidColor = errors.New("color: invalid color")
+
+type Color struct{}
+
+func New() *Color {
+	return &Color{}
+}
+
+// RGBA is an 8-bit sRGB color with straight (not premultiplied) alpha,
+// where A is 255 for an opaque color.
+type RGBA struct {
+	R, G, B, A uint8
+}
+
+// Parse reads a CSS color: #rgb, #rgba, #rrggbb and #rrggbbaa with or
+// without the #, rgb(), rgba(), hsl() and hsla() in comma or space syntax,
+// and the CSS named colors, including transparent. Matching is
+// case-insensitive.
+func (c *Color) Parse(str string) (RGBA, error) {
+	s := strings.ToLower(strings.TrimSpace(str))
+
+	if v, ok := namedColors[s]; ok {
+		return RGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+	}
+
+	name, args, ok := strings.Cut(s, "(")
+	if !ok {
+		return parseHex(str, s)
+	}
+	if !strings.HasSuffix(args, ")") {
+		return RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, str)
+	}
+
+	fields := strings.Fields(strings.NewReplacer(",", " ", "/", " ").Replace(strings.TrimSuffix(args, ")")))
+	if len(fields) != 3 && len(fields) != 4 {
+		return RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, str)
+	}
+
+	var (
+		col RGBA
+		err error
+	)
+	switch name {
+	case "rgb", "rgba":
+		col, err = parseRGB(fields)
+	case "hsl", "hsla":
+		col, err = parseHSL(fields)
+	default:
+		err = errors.New("unknown function")
+	}
+	if err != nil {
+		return RGBA{}, fmt.Errorf("%w: %q: %v", ErrInvalidColor, str, err)
+	}
+	return col, nil
+}
+
+// FromHSL builds an opaque color from a hue in degrees and saturation and
+// lightness between 0 and 1.
+func (c *Color) FromHSL(h, s, l float64) RGBA {
+	return hslToRGB(h, clamp01(s), clamp01(l), 255)
+}
+
+func (c *Color) IsColor(v string) bool {
+	_, err := c.Parse(v)
+	return err == nil
+}
+
+// Hex returns #rrggbb, or #rrggbbaa when the color is not opaque.
+func (r RGBA) Hex() string {
+	if r.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", r.R, r.G, r.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", r.R, r.G, r.B, r.A)
+}
+
+func (r RGBA) String() string {
+	return r.Hex()
+}
+
+// ARGB returns AARRGGBB in upper case, the form OOXML stores in rgb
+// attributes. Style options such as excelize.Fill.Color take Hex instead,
+// as excelize adds the FF alpha itself.
+func (r RGBA) ARGB() string {
+	return fmt.Sprintf("%02X%02X%02X%02X", r.A, r.R, r.G, r.B)
+}
+
+// RGBString returns rgb(r, g, b), or rgba(r, g, b, a) when the color is not
+// opaque.
+func (r RGBA) RGBString() string {
+	if r.A == 255 {
+		return fmt.Sprintf("rgb(%d, %d, %d)", r.R, r.G, r.B)
+	}
+	return fmt.Sprintf("rgba(%d, %d, %d, %s)", r.R, r.G, r.B, formatAlpha(r.A))
+}
+
+// HSL returns the hue in degrees and saturation and lightness between 0
+// and 1.
+func (r RGBA) HSL() (h, s, l float64) {
+	rf, gf, bf := float64(r.R)/255, float64(r.G)/255, float64(r.B)/255
+	maxC, minC := max(rf, gf, bf), min(rf, gf, bf)
+	l = (maxC + minC) / 2
+
+	d := maxC - minC
+	if d == 0 {
+		return 0, 0, l
+	}
+
+	s = d / (1 - math.Abs(2*l-1))
+	switch maxC {
+	case rf:
+		h = math.Mod((gf-bf)/d, 6)
+	case gf:
+		h = (bf-rf)/d + 2
+	default:
+		h = (rf-gf)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return h, s, l
+}
+
+// HSLString returns hsl(h, s%, l%), or hsla(h, s%, l%, a) when the color is
+// not opaque, with whole-number components.
+func (r RGBA) HSLString() string {
+	h, s, l := r.HSL()
+	hs := fmt.Sprintf("%.0f, %.0f%%, %.0f%%", math.Round(h), s*100, l*100)
+	if r.A == 255 {
+		return "hsl(" + hs + ")"
+	}
+	return "hsla(" + hs + ", " + formatAlpha(r.A) + ")"
+}
+
+// Lighten raises the HSL lightness by amount, between 0 and 1.
+func (r RGBA) Lighten(amount float64) RGBA {
+	h, s, l := r.HSL()
+	return hslToRGB(h, s, clamp01(l+amount), r.A)
+}
+
+// Darken lowers the HSL lightness by amount, between 0 and 1.
+func (r RGBA) Darken(amount float64) RGBA {
+	return r.Lighten(-amount)
+}
+
+// Mix blends r with other channel by channel; weight is the share of other,
+// so 0 returns r and 1 returns other.
+func (r RGBA) Mix(other RGBA, weight float64) RGBA {
+	w := clamp01(weight)
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*(1-w) + float64(b)*w))
+	}
+	return RGBA{R: mix(r.R, other.R), G: mix(r.G, other.G), B: mix(r.B, other.B), A: mix(r.A, other.A)}
+}
+
+// Luminance returns the WCAG relative luminance, from 0 for black to 1 for
+// white. Alpha is ignored.
+func (r RGBA) Luminance() float64 {
+	linear := func(v uint8) float64 {
+		c := float64(v) / 255
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r.R) + 0.7152*linear(r.G) + 0.0722*linear(r.B)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between r and other, from 1
+// to 21. WCAG AA asks for at least 4.5 for body text and 3 for large text.
+func (r RGBA) ContrastRatio(other RGBA) float64 {
+	l1, l2 := r.Luminance(), other.Luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+func parseHex(orig, s string) (RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+
+	switch len(s) {
+	case 3, 4:
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]}) + strings.Repeat(s[3:], 2)
+	case 6, 8:
+	default:
+		return RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, orig)
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return RGBA{}, fmt.Errorf("%w: %q", ErrInvalidColor, orig)
+	}
+	if len(s) == 6 {
+		v = v<<8 | 0xff
+	}
+	return RGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+func parseRGB(fields []string) (RGBA, error) {
+	var ch [3]uint8
+	for i := range ch {
+		v, err := parseNumber(fields[i], 255)
+		if err != nil {
+			return RGBA{}, err
+		}
+		ch[i] = uint8(math.Round(clamp(v, 0, 255)))
+	}
+
+	a, err := parseAlpha(fields)
+	if err != nil {
+		return RGBA{}, err
+	}
+	return RGBA{R: ch[0], G: ch[1], B: ch[2], A: a}, nil
+}
+
+func parseHSL(fields []string) (RGBA, error) {
+	h, err := parseNumber(strings.TrimSuffix(fields[0], "deg"), 1)
+	if err != nil {
+		return RGBA{}, err
+	}
+	if !strings.HasSuffix(fields[1], "%") || !strings.HasSuffix(fields[2], "%") {
+		return RGBA{}, errors.New("saturation and lightness must be percentages")
+	}
+	s, err := parseNumber(fields[1], 1)
+	if err != nil {
+		return RGBA{}, err
+	}
+	l, err := parseNumber(fields[2], 1)
+	if err != nil {
+		return RGBA{}, err
+	}
+
+	a, err := parseAlpha(fields)
+	if err != nil {
+		return RGBA{}, err
+	}
+	return hslToRGB(h, clamp01(s), clamp01(l), a), nil
+}
+
+// parseNumber reads a finite plain number or a percentage of scale.
+func parseNumber(field string, scale float64) (float64, error) {
+	p, percent := strings.CutSuffix(field, "%")
+
+	v, err := strconv.ParseFloat(p, 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%q is not a finite number", field)
+	}
+
+	if percent {
+		return v / 100 * scale, nil
+	}
+	return v, nil
+}
+
+func parseAlpha(fields []string) (uint8, error) {
+	if len(fields) < 4 {
+		return 255, nil
+	}
+	a, err := parseNumber(fields[3], 1)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(math.Round(clamp01(a) * 255)), nil
+}
+
+func hslToRGB(h, s, l float64, a uint8) RGBA {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	to8 := func(v float64) uint8 { return uint8(math.Round(clamp01(v+m) * 255)) }
+	return RGBA{R: to8(r), G: to8(g), B: to8(b), A: a}
+}
+
+func formatAlpha(a uint8) string {
+	return strconv.FormatFloat(math.Round(float64(a)/255*1000)/1000, 'f', -1, 64)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+func clamp01(v float64) float64 {
+	return clamp(v, 0, 1)
+}
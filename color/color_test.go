@@ -0,0 +1,179 @@
+package color
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParse(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		input    string
+		expected RGBA
+	}{
+		{"#ff8800", RGBA{255, 136, 0, 255}},
+		{"FF8800", RGBA{255, 136, 0, 255}},
+		{"#f80", RGBA{255, 136, 0, 255}},
+		{"#f808", RGBA{255, 136, 0, 136}},
+		{"#FF880080", RGBA{255, 136, 0, 128}},
+		{"rgb(255, 136, 0)", RGBA{255, 136, 0, 255}},
+		{"RGB(100%, 0%, 50%)", RGBA{255, 0, 128, 255}},
+		{"rgba(255, 136, 0, 0.5)", RGBA{255, 136, 0, 128}},
+		{"rgb(255 136 0 / 50%)", RGBA{255, 136, 0, 128}},
+		{"rgb(300, -5, 0)", RGBA{255, 0, 0, 255}},
+		{"hsl(120, 100%, 25%)", RGBA{0, 128, 0, 255}},
+		{"hsl(210deg 50% 40% / 0.25)", RGBA{51, 102, 153, 64}},
+		{"hsla(-120, 100%, 50%, 1)", RGBA{0, 0, 255, 255}},
+		{" RebeccaPurple ", RGBA{102, 51, 153, 255}},
+		{"transparent", RGBA{0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := c.Parse(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.expected {
+				t.Errorf("Parse(%q) = %+v; want %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	invalid := []string{"", "#ff888", "#gg8800", "notacolor", "rgb(1, 2)", "rgb(1, 2, 3", "cmyk(0, 0, 0, 0)", "hsl(120, 100, 50)", "rgb(nan, 0, 0)"}
+	for _, v := range invalid {
+		if _, err := c.Parse(v); !errors.Is(err, ErrInvalidColor) {
+			t.Errorf("Parse(%q) error = %v; want ErrInvalidColor", v, err)
+		}
+		if c.IsColor(v) {
+			t.Errorf("IsColor(%q) = true; want false", v)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	opaque := RGBA{51, 102, 153, 255}
+	translucent := RGBA{51, 102, 153, 128}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"Hex", opaque.Hex(), "#336699"},
+		{"HexAlpha", translucent.Hex(), "#33669980"},
+		{"String", opaque.String(), "#336699"},
+		{"ARGB", opaque.ARGB(), "FF336699"},
+		{"ARGBAlpha", translucent.ARGB(), "80336699"},
+		{"RGBString", opaque.RGBString(), "rgb(51, 102, 153)"},
+		{"RGBAString", translucent.RGBString(), "rgba(51, 102, 153, 0.502)"},
+		{"HSLString", opaque.HSLString(), "hsl(210, 50%, 40%)"},
+		{"HSLAString", translucent.HSLString(), "hsla(210, 50%, 40%, 0.502)"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %s; want %s", tt.name, tt.got, tt.expected)
+		}
+	}
+
+	c := New()
+	for _, col := range []RGBA{opaque, translucent, {0, 0, 0, 255}, {255, 255, 255, 0}, {12, 200, 77, 255}} {
+		for _, s := range []string{col.Hex(), col.RGBString()} {
+			if got, err := c.Parse(s); err != nil || got != col {
+				t.Errorf("Parse(%s) = %+v, %v; want %+v", s, got, err, col)
+			}
+		}
+	}
+}
+
+func TestHSL(t *testing.T) {
+	c := New()
+
+	for _, col := range []RGBA{{255, 0, 0, 255}, {51, 102, 153, 255}, {128, 128, 128, 255}, {250, 128, 114, 255}} {
+		h, s, l := col.HSL()
+		if got := c.FromHSL(h, s, l); got != col {
+			t.Errorf("FromHSL(HSL(%+v)) = %+v", col, got)
+		}
+	}
+}
+
+func TestManipulation(t *testing.T) {
+	c := New()
+	blue, _ := c.Parse("hsl(210, 50%, 40%)")
+
+	if got := blue.Lighten(0.2).HSLString(); got != "hsl(210, 50%, 60%)" {
+		t.Errorf("Lighten = %s; want hsl(210, 50%%, 60%%)", got)
+	}
+	if got, want := blue.Darken(0.2), c.FromHSL(210, 0.5, 0.2); got != want {
+		t.Errorf("Darken = %+v; want %+v", got, want)
+	}
+	if got := blue.Lighten(1); got != (RGBA{255, 255, 255, 255}) {
+		t.Errorf("Lighten(1) = %+v; want white", got)
+	}
+
+	black, white := RGBA{0, 0, 0, 255}, RGBA{255, 255, 255, 255}
+	if got := black.Mix(white, 0.5); got != (RGBA{128, 128, 128, 255}) {
+		t.Errorf("Mix = %+v; want {128 128 128 255}", got)
+	}
+	if got := black.Mix(white, 0); got != black {
+		t.Errorf("Mix(0) = %+v; want black", got)
+	}
+	if got := black.Mix(RGBA{255, 0, 0, 0}, 0.25); got != (RGBA{64, 0, 0, 191}) {
+		t.Errorf("Mix(0.25) = %+v; want {64 0 0 191}", got)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	c := New()
+
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"black", "white", 21},
+		{"white", "white", 1},
+		{"#767676", "white", 4.54},
+		{"white", "#0000ff", 8.59},
+		{"#777", "#fff", 4.48},
+	}
+
+	for _, tt := range tests {
+		a, _ := c.Parse(tt.a)
+		b, _ := c.Parse(tt.b)
+		if got := a.ContrastRatio(b); math.Abs(got-tt.expected) > 0.01 {
+			t.Errorf("ContrastRatio(%s, %s) = %.3f; want %.2f", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestExcelizeStyle(t *testing.T) {
+	col, _ := New().Parse("tomato")
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	id, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Color: col.Hex()},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{col.Hex()}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	style, err := f.GetStyle(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// excelize stores the colors as ARGB and reports them back without the alpha.
+	if got := style.Font.Color; got != col.ARGB()[2:] {
+		t.Errorf("font color = %s; want %s", got, col.ARGB()[2:])
+	}
+	if got := style.Fill.Color; len(got) != 1 || got[0] != col.ARGB()[2:] {
+		t.Errorf("fill color = %v; want [%s]", got, col.ARGB()[2:])
+	}
+}
@@ -0,0 +1,311 @@
+package ec
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/thiagozs/go-xutils/jwk"
+)
+
+const (
+	pemTypeECPrivateKey = "EC PRIVATE KEY"
+	pemTypePrivateKey   = "PRIVATE KEY"
+	pemTypePublicKey    = "PUBLIC KEY"
+)
+
+var (
+	ErrInvalidPem       = errors.New("ec: failed to parse PEM block containing the key")
+	ErrUnsupportedKey   = errors.New("ec: key type is not ECDSA or Ed25519")
+	ErrInvalidSignature = errors.New("ec: invalid signature")
+)
+
+// ECPem is the ECDSA and Ed25519 counterpart of rsa.RSAPem.
+type ECPem struct{}
+
+func NewPem() *ECPem {
+	return &ECPem{}
+}
+
+// ECDSAGenKeyPair generates a key on curve; P-256 is used when curve is nil.
+func (e *ECPem) ECDSAGenKeyPair(curve elliptic.Curve) (*ecdsa.PrivateKey, *ecdsa.PublicKey, error) {
+	if curve == nil {
+		curve = elliptic.P256()
+	}
+
+	privkey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privkey, &privkey.PublicKey, nil
+}
+
+func (e *ECPem) Ed25519GenKeyPair() (ed25519.PrivateKey, ed25519.PublicKey, error) {
+	pubkey, privkey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privkey, pubkey, nil
+}
+
+// ExportPrivateKeyAsPem exports an *ecdsa.PrivateKey or ed25519.PrivateKey
+// as PKCS#8 ("PRIVATE KEY").
+func (e *ECPem) ExportPrivateKeyAsPem(privkey crypto.PrivateKey) (string, error) {
+	if err := checkPrivateKey(privkey); err != nil {
+		return "", err
+	}
+
+	privkey_bytes, err := x509.MarshalPKCS8PrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: privkey_bytes})), nil
+}
+
+// ExportECPrivateKeyAsPem exports privkey as SEC 1 ("EC PRIVATE KEY"), the
+// format written by `openssl ecparam -genkey`.
+func (e *ECPem) ExportECPrivateKeyAsPem(privkey *ecdsa.PrivateKey) (string, error) {
+	privkey_bytes, err := x509.MarshalECPrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeECPrivateKey, Bytes: privkey_bytes})), nil
+}
+
+// ParsePrivateKeyFromPem accepts PKCS#8 and SEC 1 keys and returns an
+// *ecdsa.PrivateKey or ed25519.PrivateKey.
+func (e *ECPem) ParsePrivateKeyFromPem(str string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(str))
+	if block == nil {
+		return nil, ErrInvalidPem
+	}
+
+	if priv, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return priv, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+// ExportPublicKeyAsPem exports an *ecdsa.PublicKey or ed25519.PublicKey as
+// PKIX ("PUBLIC KEY").
+func (e *ECPem) ExportPublicKeyAsPem(pubkey crypto.PublicKey) (string, error) {
+	if err := checkPublicKey(pubkey); err != nil {
+		return "", err
+	}
+
+	pubkey_bytes, err := x509.MarshalPKIXPublicKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: pubkey_bytes})), nil
+}
+
+// ParsePublicKeyFromPem returns an *ecdsa.PublicKey or ed25519.PublicKey.
+func (e *ECPem) ParsePublicKeyFromPem(str string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(str))
+	if block == nil {
+		return nil, ErrInvalidPem
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPublicKey(pub); err != nil {
+		return nil, err
+	}
+
+	return pub, nil
+}
+
+// ExportPrivateKeyAsJWK exports the key as a JWK document whose kid is the
+// RFC 7638 thumbprint.
+func (e *ECPem) ExportPrivateKeyAsJWK(privkey crypto.PrivateKey) (string, error) {
+	if err := checkPrivateKey(privkey); err != nil {
+		return "", err
+	}
+
+	k, err := jwk.New().FromPrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalJWK(k)
+}
+
+func (e *ECPem) ExportPublicKeyAsJWK(pubkey crypto.PublicKey) (string, error) {
+	if err := checkPublicKey(pubkey); err != nil {
+		return "", err
+	}
+
+	k, err := jwk.New().FromPublicKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+
+	return marshalJWK(k)
+}
+
+func (e *ECPem) ParsePrivateKeyFromJWK(str string) (crypto.Signer, error) {
+	k, err := jwk.New().Parse([]byte(str))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := k.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+func (e *ECPem) ParsePublicKeyFromJWK(str string) (crypto.PublicKey, error) {
+	k, err := jwk.New().Parse([]byte(str))
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPublicKey(pub); err != nil {
+		return nil, err
+	}
+
+	return pub, nil
+}
+
+// Sign signs data and returns the signature as URL-safe base64. ECDSA
+// signatures are ASN.1 DER over the curve's matching SHA-2 digest (SHA-256
+// for P-256, SHA-384 for P-384, SHA-512 for P-521); Ed25519 signs data
+// directly.
+func (e *ECPem) Sign(privkey crypto.PrivateKey, data string) (string, error) {
+	var sig []byte
+	switch priv := privkey.(type) {
+	case *ecdsa.PrivateKey:
+		digest, err := ecdsaDigest(priv.Curve, []byte(data))
+		if err != nil {
+			return "", err
+		}
+
+		if sig, err = ecdsa.SignASN1(rand.Reader, priv, digest); err != nil {
+			return "", err
+		}
+	case ed25519.PrivateKey:
+		if len(priv) != ed25519.PrivateKeySize {
+			return "", ErrUnsupportedKey
+		}
+		sig = ed25519.Sign(priv, []byte(data))
+	default:
+		return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, privkey)
+	}
+
+	return base64.URLEncoding.EncodeToString(sig), nil
+}
+
+// Verify checks a signature produced by Sign. A nil error means the
+// signature is valid.
+func (e *ECPem) Verify(pubkey crypto.PublicKey, data, signature string) error {
+	sig, err := base64.URLEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+
+	switch pub := pubkey.(type) {
+	case *ecdsa.PublicKey:
+		digest, err := ecdsaDigest(pub.Curve, []byte(data))
+		if err != nil {
+			return err
+		}
+
+		if !ecdsa.VerifyASN1(pub, digest, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case ed25519.PublicKey:
+		if len(pub) != ed25519.PublicKeySize || !ed25519.Verify(pub, []byte(data), sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %T", ErrUnsupportedKey, pubkey)
+}
+
+func ecdsaDigest(curve elliptic.Curve, data []byte) ([]byte, error) {
+	switch curve {
+	case elliptic.P256():
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	case elliptic.P384():
+		sum := sha512.Sum384(data)
+		return sum[:], nil
+	case elliptic.P521():
+		sum := sha512.Sum512(data)
+		return sum[:], nil
+	}
+	return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, curve.Params().Name)
+}
+
+func checkPrivateKey(key crypto.PrivateKey) error {
+	switch key.(type) {
+	case *ecdsa.PrivateKey, ed25519.PrivateKey:
+		return nil
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+func checkPublicKey(key crypto.PublicKey) error {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey:
+		return nil
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+}
+
+func marshalJWK(k *jwk.Key) (string, error) {
+	b, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -12,6 +12,7 @@ require (
 require (
 	github.com/golang/protobuf v1.5.3 // indirect
 	github.com/ttacon/builder v0.0.0-20170518171403-c099f663e1c2 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 	google.golang.org/protobuf v1.26.0 // indirect
 )
 
@@ -25,7 +26,7 @@ require (
 	github.com/ttacon/libphonenumber v1.2.1
 	github.com/xuri/efp v0.0.0-20240408161823-9ad904a10d6d // indirect
 	github.com/xuri/nfp v0.0.0-20240318013403-ab9948c2c4a7 // indirect
-	golang.org/x/crypto v0.28.0 // indirect
+	golang.org/x/crypto v0.28.0
 	golang.org/x/net v0.30.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
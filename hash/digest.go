@@ -0,0 +1,213 @@
+package hash
+
+import (
+	"crypto/hmac"
+	cmd5 "crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	stdhash "hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+type Algorithm string
+
+const (
+	AlgMD5      Algorithm = "md5"
+	AlgSHA1     Algorithm = "sha1"
+	AlgSHA256   Algorithm = "sha256"
+	AlgSHA512   Algorithm = "sha512"
+	AlgSHA3_256 Algorithm = "sha3-256"
+	AlgBLAKE2b  Algorithm = "blake2b-512"
+)
+
+// New returns a fresh hash.Hash for the algorithm.
+func (a Algorithm) New() (stdhash.Hash, error) {
+	switch a {
+	case AlgMD5:
+		return cmd5.New(), nil
+	case AlgSHA1:
+		return sha1.New(), nil
+	case AlgSHA256:
+		return sha256.New(), nil
+	case AlgSHA512:
+		return sha512.New(), nil
+	case AlgSHA3_256:
+		return sha3.New256(), nil
+	case AlgBLAKE2b:
+		return blake2b.New512(nil)
+	}
+	return nil, fmt.Errorf("hash: unknown algorithm %q", a)
+}
+
+// HexLen is the length of the algorithm's hex digest, or 0 if unknown.
+func (a Algorithm) HexLen() int {
+	switch a {
+	case AlgMD5:
+		return 32
+	case AlgSHA1:
+		return 40
+	case AlgSHA256, AlgSHA3_256:
+		return 64
+	case AlgSHA512, AlgBLAKE2b:
+		return 128
+	}
+	return 0
+}
+
+// Sum returns the raw digest of data.
+func (h *Hash) Sum(alg Algorithm, data []byte) ([]byte, error) {
+	s, err := alg.New()
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = s.Write(data)
+	return s.Sum(nil), nil
+}
+
+// SHA1 is for interoperability with legacy checksums only; SHA-1 is not
+// collision resistant.
+func (h *Hash) SHA1(str string) string {
+	sum := sha1.Sum([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA1Base64(str string) string {
+	sum := sha1.Sum([]byte(str))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA256(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA256Base64(str string) string {
+	sum := sha256.Sum256([]byte(str))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA512(str string) string {
+	sum := sha512.Sum512([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA512Base64(str string) string {
+	sum := sha512.Sum512([]byte(str))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA3_256(str string) string {
+	sum := sha3.Sum256([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *Hash) SHA3_256Base64(str string) string {
+	sum := sha3.Sum256([]byte(str))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// BLAKE2b computes the 512 bit BLAKE2b digest.
+func (h *Hash) BLAKE2b(str string) string {
+	sum := blake2b.Sum512([]byte(str))
+	return hex.EncodeToString(sum[:])
+}
+
+func (h *Hash) BLAKE2bBase64(str string) string {
+	sum := blake2b.Sum512([]byte(str))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// HMAC returns the hex encoded HMAC of message under key.
+func (h *Hash) HMAC(alg Algorithm, key, message string) (string, error) {
+	mac, err := h.hmac(alg, key, message)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac), nil
+}
+
+func (h *Hash) HMACBase64(alg Algorithm, key, message string) (string, error) {
+	mac, err := h.hmac(alg, key, message)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(mac), nil
+}
+
+// VerifyHMAC checks a hex encoded HMAC in constant time.
+func (h *Hash) VerifyHMAC(alg Algorithm, key, message, mac string) bool {
+	got, err := hex.DecodeString(mac)
+	if err != nil {
+		return false
+	}
+	return h.verifyHMAC(alg, key, message, got)
+}
+
+// VerifyHMACBase64 checks a standard base64 encoded HMAC in constant time.
+func (h *Hash) VerifyHMACBase64(alg Algorithm, key, message, mac string) bool {
+	got, err := base64.StdEncoding.DecodeString(mac)
+	if err != nil {
+		return false
+	}
+	return h.verifyHMAC(alg, key, message, got)
+}
+
+func (h *Hash) verifyHMAC(alg Algorithm, key, message string, got []byte) bool {
+	want, err := h.hmac(alg, key, message)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, want)
+}
+
+func (h *Hash) hmac(alg Algorithm, key, message string) ([]byte, error) {
+	if _, err := alg.New(); err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(func() stdhash.Hash {
+		s, _ := alg.New()
+		return s
+	}, []byte(key))
+	_, _ = mac.Write([]byte(message))
+	return mac.Sum(nil), nil
+}
+
+func (h *Hash) IsSHA1(v string) bool {
+	return len(v) == 40 && isHexDigest(v)
+}
+
+func (h *Hash) IsSHA256(v string) bool {
+	return len(v) == 64 && isHexDigest(v)
+}
+
+func (h *Hash) IsSHA512(v string) bool {
+	return len(v) == 128 && isHexDigest(v)
+}
+
+// IsSHA3_256 checks the format only, so it cannot tell SHA3-256 and SHA-256
+// digests apart.
+func (h *Hash) IsSHA3_256(v string) bool {
+	return len(v) == 64 && isHexDigest(v)
+}
+
+func (h *Hash) IsBLAKE2b(v string) bool {
+	return len(v) == 128 && isHexDigest(v)
+}
+
+// isHexDigest accepts only plain hex digits, unlike IsHex, which also allows
+// a # or 0x prefix.
+func isHexDigest(v string) bool {
+	if v == "" || len(v)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(v)
+	return err == nil
+}
@@ -0,0 +1,118 @@
+package hash
+
+import (
+	"testing"
+)
+
+func TestDigests(t *testing.T) {
+	hasher := New()
+	str := "hello"
+
+	tests := []struct {
+		name     string
+		fn       func(string) string
+		expected string
+	}{
+		{"SHA1", hasher.SHA1, "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d"},
+		{"SHA256", hasher.SHA256, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"SHA512", hasher.SHA512, "9b71d224bd62f3785d96d46ad3ea3d73319bfbc2890caadae2dff72519673ca72323c3d99ba5c11d7c7acc6e14b8c5da0c4663475c2e5c3adef46f73bcdec043"},
+		{"SHA3_256", hasher.SHA3_256, "3338be694f50c5f338814986cdf0686453a888b84f424d792af4b9202398f392"},
+		{"BLAKE2b", hasher.BLAKE2b, "e4cfa39a3d37be31c59609e807970799caa68a19bfaa15135f165085e01d41a65ba1e1b146aeb6bd0092b49eac214c103ccfa3a365954bbbe52f74a2b3620c94"},
+		{"SHA256Base64", hasher.SHA256Base64, "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(str); got != tt.expected {
+				t.Errorf("%s(%s) = %s; want %s", tt.name, str, got, tt.expected)
+			}
+		})
+	}
+
+	for _, alg := range []Algorithm{AlgMD5, AlgSHA1, AlgSHA256, AlgSHA512, AlgSHA3_256, AlgBLAKE2b} {
+		sum, err := hasher.Sum(alg, []byte(str))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sum)*2 != alg.HexLen() {
+			t.Errorf("Sum(%s) has %d bytes; want %d", alg, len(sum), alg.HexLen()/2)
+		}
+	}
+
+	if _, err := hasher.Sum("crc32", nil); err == nil {
+		t.Error("expected error for unknown algorithm")
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	hasher := New()
+
+	// RFC 4231 test case 2.
+	key, msg := "Jefe", "what do ya want for nothing?"
+	expected := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+
+	mac, err := hasher.HMAC(AlgSHA256, key, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mac != expected {
+		t.Errorf("HMAC = %s; want %s", mac, expected)
+	}
+
+	if !hasher.VerifyHMAC(AlgSHA256, key, msg, expected) {
+		t.Error("VerifyHMAC = false; want true")
+	}
+
+	if hasher.VerifyHMAC(AlgSHA256, key, msg+".", expected) {
+		t.Error("VerifyHMAC with other message = true; want false")
+	}
+
+	if hasher.VerifyHMAC(AlgSHA256, key, msg, "zz") {
+		t.Error("VerifyHMAC with invalid hex = true; want false")
+	}
+
+	b64, err := hasher.HMACBase64(AlgBLAKE2b, key, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !hasher.VerifyHMACBase64(AlgBLAKE2b, key, msg, b64) {
+		t.Error("VerifyHMACBase64 = false; want true")
+	}
+
+	if _, err := hasher.HMAC("none", key, msg); err == nil {
+		t.Error("expected error for unknown algorithm")
+	}
+}
+
+func TestIsDigest(t *testing.T) {
+	hasher := New()
+
+	tests := []struct {
+		name    string
+		fn      func(string) bool
+		valid   string
+		invalid string
+	}{
+		{"IsSHA1", hasher.IsSHA1, hasher.SHA1("x"), hasher.MD5("x")},
+		{"IsSHA256", hasher.IsSHA256, hasher.SHA256("x"), hasher.SHA1("x")},
+		{"IsSHA512", hasher.IsSHA512, hasher.SHA512("x"), hasher.SHA256("x")},
+		{"IsSHA3_256", hasher.IsSHA3_256, hasher.SHA3_256("x"), "z" + hasher.SHA3_256("x")[1:]},
+		{"IsBLAKE2b", hasher.IsBLAKE2b, hasher.BLAKE2b("x"), hasher.SHA1("x")},
+	}
+
+	for _, tt := range tests {
+		if !tt.fn(tt.valid) {
+			t.Errorf("%s(%s) = false; want true", tt.name, tt.valid)
+		}
+		if tt.fn(tt.invalid) {
+			t.Errorf("%s(%s) = true; want false", tt.name, tt.invalid)
+		}
+		for _, prefixed := range []string{"0x" + tt.valid[2:], "#" + tt.valid[1:]} {
+			if tt.fn(prefixed) {
+				t.Errorf("%s(%s) = true; want false", tt.name, prefixed)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	base58ChecksumSize = 4
+)
+
+var (
+	ErrInvalidEncoding = errors.New("hash: invalid encoded string")
+	ErrInvalidChecksum = errors.New("hash: checksum mismatch")
+
+	base58Index    = alphabetIndex(base58Alphabet)
+	base62Index    = alphabetIndex(base62Alphabet)
+	crockfordIndex = alphabetIndex(crockfordAlphabet)
+
+	crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+	// Crockford decoding is case-insensitive, reads I and L as 1 and O as 0,
+	// and ignores hyphens used to group symbols for readability.
+	crockfordReplacer = strings.NewReplacer("-", "", "I", "1", "L", "1", "O", "0")
+)
+
+// Base32Encode encodes data with the RFC 4648 standard alphabet and padding.
+func (h *Hash) Base32Encode(data []byte) string {
+	return base32.StdEncoding.EncodeToString(data)
+}
+
+func (h *Hash) Base32Decode(str string) ([]byte, error) {
+	data, err := base32.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	return data, nil
+}
+
+func (h *Hash) IsBase32(v string) bool {
+	_, err := h.Base32Decode(v)
+	return v != "" && err == nil
+}
+
+// Base58Encode uses the Bitcoin alphabet, which leaves out 0, O, I and l.
+// Leading zero bytes are kept as leading '1' characters.
+func (h *Hash) Base58Encode(data []byte) string {
+	return encodeBaseN(data, base58Alphabet)
+}
+
+func (h *Hash) Base58Decode(str string) ([]byte, error) {
+	return decodeBaseN(str, base58Alphabet, &base58Index)
+}
+
+func (h *Hash) IsBase58(v string) bool {
+	_, err := h.Base58Decode(v)
+	return v != "" && err == nil
+}
+
+// Base58CheckEncode appends the first four bytes of SHA-256(SHA-256(data))
+// before encoding, as Bitcoin addresses do. Include any version byte in data.
+func (h *Hash) Base58CheckEncode(data []byte) string {
+	sum := base58Checksum(data)
+	return h.Base58Encode(append(append([]byte(nil), data...), sum[:]...))
+}
+
+// Base58CheckDecode decodes str and verifies and strips its checksum.
+func (h *Hash) Base58CheckDecode(str string) ([]byte, error) {
+	raw, err := h.Base58Decode(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) < base58ChecksumSize {
+		return nil, fmt.Errorf("%w: too short for a checksum", ErrInvalidEncoding)
+	}
+
+	data, got := raw[:len(raw)-base58ChecksumSize], raw[len(raw)-base58ChecksumSize:]
+	want := base58Checksum(data)
+	if subtle.ConstantTimeCompare(got, want[:]) != 1 {
+		return nil, ErrInvalidChecksum
+	}
+	return data, nil
+}
+
+func (h *Hash) IsBase58Check(v string) bool {
+	_, err := h.Base58CheckDecode(v)
+	return err == nil
+}
+
+// Base62Encode uses 0-9, A-Z and a-z, so the output is safe in URLs and
+// file names without escaping. Leading zero bytes are kept as leading '0'
+// characters.
+func (h *Hash) Base62Encode(data []byte) string {
+	return encodeBaseN(data, base62Alphabet)
+}
+
+func (h *Hash) Base62Decode(str string) ([]byte, error) {
+	return decodeBaseN(str, base62Alphabet, &base62Index)
+}
+
+func (h *Hash) IsBase62(v string) bool {
+	_, err := h.Base62Decode(v)
+	return v != "" && err == nil
+}
+
+// Base62EncodeUint64 encodes n as a short numeric code, e.g. for public IDs.
+func (h *Hash) Base62EncodeUint64(n uint64) string {
+	return encodeUint64(n, base62Alphabet)
+}
+
+func (h *Hash) Base62DecodeUint64(str string) (uint64, error) {
+	return decodeUint64(str, base62Alphabet, &base62Index)
+}
+
+// CrockfordEncode encodes data with Crockford's Base32 alphabet, without
+// padding. The output avoids I, L, O and U, so it reads well aloud.
+func (h *Hash) CrockfordEncode(data []byte) string {
+	return crockfordEncoding.EncodeToString(data)
+}
+
+func (h *Hash) CrockfordDecode(str string) ([]byte, error) {
+	data, err := crockfordEncoding.DecodeString(normalizeCrockford(str))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	return data, nil
+}
+
+func (h *Hash) IsCrockford(v string) bool {
+	_, err := h.CrockfordDecode(v)
+	return v != "" && err == nil
+}
+
+// CrockfordEncodeUint64 encodes n as a Crockford Base32 number, the form
+// used for invite codes and ULIDs.
+func (h *Hash) CrockfordEncodeUint64(n uint64) string {
+	return encodeUint64(n, crockfordAlphabet)
+}
+
+func (h *Hash) CrockfordDecodeUint64(str string) (uint64, error) {
+	return decodeUint64(normalizeCrockford(str), crockfordAlphabet, &crockfordIndex)
+}
+
+func normalizeCrockford(str string) string {
+	return crockfordReplacer.Replace(strings.ToUpper(str))
+}
+
+func base58Checksum(data []byte) [base58ChecksumSize]byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return [base58ChecksumSize]byte(second[:base58ChecksumSize])
+}
+
+func alphabetIndex(alphabet string) [256]int8 {
+	var index [256]int8
+	for i := range index {
+		index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		index[alphabet[i]] = int8(i)
+	}
+	return index
+}
+
+// encodeBaseN treats data as a big-endian number and writes it in base
+// len(alphabet). It is quadratic in the input length, which is fine for the
+// short identifiers it is meant for.
+func encodeBaseN(data []byte, alphabet string) string {
+	base := len(alphabet)
+
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	// Digits in the output base, least significant first.
+	digits := make([]byte, 0, len(data)*138/100+1)
+	for _, b := range data[zeros:] {
+		carry := int(b)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % base)
+			carry /= base
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%base))
+			carry /= base
+		}
+	}
+
+	var sb strings.Builder
+	sb.Grow(zeros + len(digits))
+	for i := 0; i < zeros; i++ {
+		sb.WriteByte(alphabet[0])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		sb.WriteByte(alphabet[digits[i]])
+	}
+	return sb.String()
+}
+
+func decodeBaseN(str string, alphabet string, index *[256]int8) ([]byte, error) {
+	base := len(alphabet)
+
+	zeros := 0
+	for zeros < len(str) && str[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	// Bytes of the decoded number, least significant first.
+	out := make([]byte, 0, len(str))
+	for i := zeros; i < len(str); i++ {
+		v := index[str[i]]
+		if v < 0 {
+			return nil, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidEncoding, str[i], i)
+		}
+
+		carry := int(v)
+		for j := range out {
+			carry += int(out[j]) * base
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			out = append(out, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	result := make([]byte, zeros+len(out))
+	for i, b := range out {
+		result[len(result)-1-i] = b
+	}
+	return result, nil
+}
+
+func encodeUint64(n uint64, alphabet string) string {
+	base := uint64(len(alphabet))
+	if n == 0 {
+		return alphabet[:1]
+	}
+
+	var buf [64]byte
+	i := len(buf)
+	for n > 0 {
+		i--
+		buf[i] = alphabet[n%base]
+		n /= base
+	}
+	return string(buf[i:])
+}
+
+func decodeUint64(str string, alphabet string, index *[256]int8) (uint64, error) {
+	if str == "" {
+		return 0, fmt.Errorf("%w: empty string", ErrInvalidEncoding)
+	}
+
+	base := uint64(len(alphabet))
+	var n uint64
+	for i := 0; i < len(str); i++ {
+		v := index[str[i]]
+		if v < 0 {
+			return 0, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidEncoding, str[i], i)
+		}
+		if n > (^uint64(0)-uint64(v))/base {
+			return 0, fmt.Errorf("%w: value overflows uint64", ErrInvalidEncoding)
+		}
+		n = n*base + uint64(v)
+	}
+	return n, nil
+}
@@ -0,0 +1,139 @@
+package hash
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestBase32(t *testing.T) {
+	hasher := New()
+
+	if got := hasher.Base32Encode([]byte("foobar")); got != "MZXW6YTBOI======" {
+		t.Errorf("Base32Encode = %s; want MZXW6YTBOI======", got)
+	}
+	if got, err := hasher.Base32Decode("MZXW6YQ="); err != nil || string(got) != "foob" {
+		t.Errorf("Base32Decode = %q, %v; want foob", got, err)
+	}
+	if _, err := hasher.Base32Decode("MZXW6YQ"); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Base32Decode error = %v; want ErrInvalidEncoding", err)
+	}
+
+	for v, want := range map[string]bool{"MZXW6YTBOI======": true, "MY======": true, "mzxw6ytboi======": false, "MZXW1": false, "": false} {
+		if got := hasher.IsBase32(v); got != want {
+			t.Errorf("IsBase32(%q) = %v; want %v", v, got, want)
+		}
+	}
+}
+
+func TestBase58(t *testing.T) {
+	hasher := New()
+
+	tests := []struct {
+		hex     string
+		encoded string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{hex.EncodeToString([]byte("simply a long string")), "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"00000000000000000000", "1111111111"},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.hex)
+		if got := hasher.Base58Encode(data); got != tt.encoded {
+			t.Errorf("Base58Encode(%s) = %s; want %s", tt.hex, got, tt.encoded)
+		}
+		if got, err := hasher.Base58Decode(tt.encoded); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("Base58Decode(%s) = %x, %v; want %s", tt.encoded, got, err, tt.hex)
+		}
+	}
+
+	for _, v := range []string{"0OIl", "abc!"} {
+		if hasher.IsBase58(v) {
+			t.Errorf("IsBase58(%q) = true; want false", v)
+		}
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	hasher := New()
+
+	payload, _ := hex.DecodeString("00010966776006953d5567439e5e39f86a0d273bee")
+	address := "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+
+	if got := hasher.Base58CheckEncode(payload); got != address {
+		t.Errorf("Base58CheckEncode = %s; want %s", got, address)
+	}
+	if got, err := hasher.Base58CheckDecode(address); err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Base58CheckDecode = %x, %v", got, err)
+	}
+	if !hasher.IsBase58Check(address) {
+		t.Error("IsBase58Check should accept a valid address")
+	}
+
+	if _, err := hasher.Base58CheckDecode("16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvN"); !errors.Is(err, ErrInvalidChecksum) {
+		t.Errorf("Base58CheckDecode error = %v; want ErrInvalidChecksum", err)
+	}
+	if _, err := hasher.Base58CheckDecode("2g"); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Base58CheckDecode error = %v; want ErrInvalidEncoding", err)
+	}
+}
+
+func TestBase62(t *testing.T) {
+	hasher := New()
+
+	data := []byte{0, 0, 0xde, 0xad, 0xbe, 0xef}
+	encoded := hasher.Base62Encode(data)
+	if encoded[:2] != "00" {
+		t.Errorf("Base62Encode = %s; want leading zeros kept", encoded)
+	}
+	if got, err := hasher.Base62Decode(encoded); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("Base62Decode(%s) = %x, %v", encoded, got, err)
+	}
+
+	numbers := map[uint64]string{0: "0", 61: "z", 62: "10", 3844: "100", 1<<64 - 1: "LygHa16AHYF"}
+	for n, want := range numbers {
+		if got := hasher.Base62EncodeUint64(n); got != want {
+			t.Errorf("Base62EncodeUint64(%d) = %s; want %s", n, got, want)
+		}
+		if got, err := hasher.Base62DecodeUint64(want); err != nil || got != n {
+			t.Errorf("Base62DecodeUint64(%s) = %d, %v; want %d", want, got, err, n)
+		}
+	}
+
+	if _, err := hasher.Base62DecodeUint64("LygHa16AHYG"); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Base62DecodeUint64 overflow error = %v; want ErrInvalidEncoding", err)
+	}
+	if hasher.IsBase62("abc-123") || !hasher.IsBase62("abc123") {
+		t.Error("IsBase62 gave the wrong answer")
+	}
+}
+
+func TestCrockford(t *testing.T) {
+	hasher := New()
+
+	if got := hasher.CrockfordEncode([]byte("foobar")); got != "CSQPYRK1E8" {
+		t.Errorf("CrockfordEncode = %s; want CSQPYRK1E8", got)
+	}
+	if got, err := hasher.CrockfordDecode("csqp-yrk1-e8"); err != nil || string(got) != "foobar" {
+		t.Errorf("CrockfordDecode = %q, %v; want foobar", got, err)
+	}
+
+	if got := hasher.CrockfordEncodeUint64(32); got != "10" {
+		t.Errorf("CrockfordEncodeUint64(32) = %s; want 10", got)
+	}
+	for _, v := range []string{"10", "1O", "lo", "I0"} {
+		if got, err := hasher.CrockfordDecodeUint64(v); err != nil || got != 32 {
+			t.Errorf("CrockfordDecodeUint64(%s) = %d, %v; want 32", v, got, err)
+		}
+	}
+
+	if hasher.IsCrockford("CSQPYRK1EU") || !hasher.IsCrockford("csqpyrk1e8") {
+		t.Error("IsCrockford gave the wrong answer")
+	}
+}
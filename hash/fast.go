@@ -0,0 +1,190 @@
+package hash
+
+import "math/bits"
+
+// Fast non-cryptographic hashes for sharding, bucketing and sketches. They
+// are not collision resistant against an adversary; use Sum or HMAC for
+// anything security related.
+
+const (
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime64  uint64 = 1099511628211
+)
+
+// FNV1a64 matches hash/fnv.New64a without allocating.
+func (h *Hash) FNV1a64(str string) uint64 {
+	sum := fnvOffset64
+	for i := 0; i < len(str); i++ {
+		sum ^= uint64(str[i])
+		sum *= fnvPrime64
+	}
+	return sum
+}
+
+func (h *Hash) XXHash64(str string) uint64 {
+	return xxhash64(str, 0)
+}
+
+func (h *Hash) XXHash64Seed(str string, seed uint64) uint64 {
+	return xxhash64(str, seed)
+}
+
+// Murmur3 returns the first half of MurmurHash3 x64_128 with seed 0, the
+// value most libraries expose as the 64-bit variant.
+func (h *Hash) Murmur3(str string) uint64 {
+	h1, _ := murmur3x64(str, 0)
+	return h1
+}
+
+func (h *Hash) Murmur3_128(str string, seed uint32) (uint64, uint64) {
+	return murmur3x64(str, seed)
+}
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+func xxhash64(s string, seed uint64) uint64 {
+	n := len(s)
+	var h uint64
+
+	if n >= 32 {
+		v1 := seed + xxPrime1 + xxPrime2
+		v2 := seed + xxPrime2
+		v3 := seed
+		v4 := seed - xxPrime1
+		for len(s) >= 32 {
+			v1 = xxRound(v1, le64(s, 0))
+			v2 = xxRound(v2, le64(s, 8))
+			v3 = xxRound(v3, le64(s, 16))
+			v4 = xxRound(v4, le64(s, 24))
+			s = s[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxMergeRound(h, v1)
+		h = xxMergeRound(h, v2)
+		h = xxMergeRound(h, v3)
+		h = xxMergeRound(h, v4)
+	} else {
+		h = seed + xxPrime5
+	}
+
+	h += uint64(n)
+
+	for ; len(s) >= 8; s = s[8:] {
+		h ^= xxRound(0, le64(s, 0))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+	}
+	if len(s) >= 4 {
+		h ^= uint64(le32(s, 0)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		s = s[4:]
+	}
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i]) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMergeRound(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+const (
+	murmurC1 uint64 = 0x87c37b91114253d5
+	murmurC2 uint64 = 0x4cf5ad432745937f
+)
+
+func murmur3x64(s string, seed uint32) (uint64, uint64) {
+	n := len(s)
+	h1, h2 := uint64(seed), uint64(seed)
+
+	for ; len(s) >= 16; s = s[16:] {
+		k1, k2 := le64(s, 0), le64(s, 8)
+
+		h1 ^= murmurMixK1(k1)
+		h1 = bits.RotateLeft64(h1, 27)
+		h1 += h2
+		h1 = h1*5 + 0x52dce729
+
+		h2 ^= murmurMixK2(k2)
+		h2 = bits.RotateLeft64(h2, 31)
+		h2 += h1
+		h2 = h2*5 + 0x38495ab5
+	}
+
+	var k1, k2 uint64
+	for i := len(s) - 1; i >= 8; i-- {
+		k2 ^= uint64(s[i]) << (8 * (i - 8))
+	}
+	if len(s) > 8 {
+		h2 ^= murmurMixK2(k2)
+	}
+	for i := min(len(s), 8) - 1; i >= 0; i-- {
+		k1 ^= uint64(s[i]) << (8 * i)
+	}
+	if len(s) > 0 {
+		h1 ^= murmurMixK1(k1)
+	}
+
+	h1 ^= uint64(n)
+	h2 ^= uint64(n)
+	h1 += h2
+	h2 += h1
+	h1 = murmurFmix(h1)
+	h2 = murmurFmix(h2)
+	h1 += h2
+	h2 += h1
+	return h1, h2
+}
+
+func murmurMixK1(k uint64) uint64 {
+	k *= murmurC1
+	k = bits.RotateLeft64(k, 31)
+	return k * murmurC2
+}
+
+func murmurMixK2(k uint64) uint64 {
+	k *= murmurC2
+	k = bits.RotateLeft64(k, 33)
+	return k * murmurC1
+}
+
+func murmurFmix(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+func le64(s string, i int) uint64 {
+	_ = s[i+7]
+	return uint64(s[i]) | uint64(s[i+1])<<8 | uint64(s[i+2])<<16 | uint64(s[i+3])<<24 |
+		uint64(s[i+4])<<32 | uint64(s[i+5])<<40 | uint64(s[i+6])<<48 | uint64(s[i+7])<<56
+}
+
+func le32(s string, i int) uint32 {
+	_ = s[i+3]
+	return uint32(s[i]) | uint32(s[i+1])<<8 | uint32(s[i+2])<<16 | uint32(s[i+3])<<24
+}
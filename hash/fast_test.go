@@ -0,0 +1,109 @@
+package hash
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFastHashes(t *testing.T) {
+	hasher := New()
+
+	tests := []struct {
+		name     string
+		fn       func(string) uint64
+		input    string
+		expected uint64
+	}{
+		{"FNV1a64 empty", hasher.FNV1a64, "", 0xcbf29ce484222325},
+		{"FNV1a64", hasher.FNV1a64, "a", 0xaf63dc4c8601ec8c},
+		{"XXHash64 empty", hasher.XXHash64, "", 0xef46db3751d8e999},
+		{"XXHash64", hasher.XXHash64, "abc", 0x44bc2cf5ad770999},
+		{"XXHash64 long", hasher.XXHash64, "Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+		{"Murmur3 empty", hasher.Murmur3, "", 0},
+		{"Murmur3", hasher.Murmur3, "hello", 0xcbd8a7b341bd9b02},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.input); got != tt.expected {
+				t.Errorf("%s(%q) = %#x; want %#x", tt.name, tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	h1, h2 := hasher.Murmur3_128("The quick brown fox jumps over the lazy dog", 0)
+	if h1 != 0xe34bbc7bbc071b6c || h2 != 0x7a433ca9c49a9347 {
+		t.Errorf("Murmur3_128 = %#x %#x", h1, h2)
+	}
+
+	if hasher.XXHash64Seed("abc", 1) == hasher.XXHash64("abc") {
+		t.Error("seed should change the xxHash64 result")
+	}
+}
+
+func TestRing(t *testing.T) {
+	hasher := New()
+
+	if _, ok := hasher.NewRing(0).Get("key"); ok {
+		t.Error("Get on an empty ring should report false")
+	}
+
+	ring := hasher.NewRing(0, "node-a", "node-b", "node-c")
+	other := hasher.NewRing(0, "node-c", "node-a", "node-b")
+
+	before := make(map[string]string)
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		node, ok := ring.Get(key)
+		if !ok {
+			t.Fatal("Get returned no node")
+		}
+		if o, _ := other.Get(key); o != node {
+			t.Fatalf("placement depends on insertion order for %s: %s vs %s", key, node, o)
+		}
+		before[key] = node
+		counts[node]++
+	}
+	for node, c := range counts {
+		if c < 700 || c > 1300 {
+			t.Errorf("%s owns %d of 3000 keys; distribution is too uneven", node, c)
+		}
+	}
+
+	ring.Add("node-d")
+	for key, prev := range before {
+		if node, _ := ring.Get(key); node != prev && node != "node-d" {
+			t.Fatalf("%s moved from %s to %s after adding node-d", key, prev, node)
+		}
+	}
+
+	ring.Remove("node-d", "node-b")
+	if got := ring.Nodes(); len(got) != 2 || got[0] != "node-a" || got[1] != "node-c" {
+		t.Fatalf("Nodes = %v", got)
+	}
+	for key, prev := range before {
+		if node, _ := ring.Get(key); prev != "node-b" && node != prev {
+			t.Fatalf("%s moved from %s to %s after removing node-b", key, prev, node)
+		}
+	}
+}
+
+func TestRingGetN(t *testing.T) {
+	ring := New().NewRing(50, "a", "b", "c")
+
+	replicas := ring.GetN("user:42", 2)
+	if len(replicas) != 2 || replicas[0] == replicas[1] {
+		t.Fatalf("GetN = %v; want two distinct nodes", replicas)
+	}
+	if owner, _ := ring.Get("user:42"); replicas[0] != owner {
+		t.Errorf("GetN[0] = %s; want owner %s", replicas[0], owner)
+	}
+
+	if got := ring.GetN("user:42", 10); len(got) != 3 {
+		t.Errorf("GetN(10) = %v; want all 3 nodes", got)
+	}
+	if got := ring.GetN("user:42", 0); got != nil {
+		t.Errorf("GetN(0) = %v; want nil", got)
+	}
+}
@@ -0,0 +1,227 @@
+package hash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordScheme string
+
+const (
+	PasswordArgon2id PasswordScheme = "argon2id"
+	PasswordBcrypt   PasswordScheme = "bcrypt"
+)
+
+// A stored hash is verified on every login, often by many requests at once,
+// so the parameters it may carry are held well above sane defaults but low
+// enough that a tampered row cannot exhaust the server: 256 MiB and 16 passes.
+const (
+	maxArgon2Memory  = 256 * 1024
+	maxArgon2Time    = 16
+	maxArgon2SaltLen = 64
+	maxArgon2KeyLen  = 128
+)
+
+var (
+	ErrInvalidPasswordParams = errors.New("hash: invalid password hashing parameters")
+	ErrInvalidPasswordHash   = errors.New("hash: invalid password hash")
+	ErrPasswordTooLong       = bcrypt.ErrPasswordTooLong
+)
+
+// PasswordParams selects the password hashing scheme and its cost. Only the
+// fields used by Scheme are read.
+type PasswordParams struct {
+	Scheme PasswordScheme
+
+	// Argon2id; Memory is in KiB
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+
+	// bcrypt
+	Cost int
+}
+
+func DefaultArgon2idParams() PasswordParams {
+	return PasswordParams{
+		Scheme:  PasswordArgon2id,
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+		SaltLen: 16,
+		KeyLen:  32,
+	}
+}
+
+func DefaultBcryptParams() PasswordParams {
+	return PasswordParams{Scheme: PasswordBcrypt, Cost: 12}
+}
+
+func (p PasswordParams) validate() error {
+	switch p.Scheme {
+	case PasswordArgon2id:
+		if p.Time == 0 || p.Time > maxArgon2Time || p.Threads == 0 ||
+			p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory {
+			return fmt.Errorf("%w: argon2id t=%d m=%d p=%d", ErrInvalidPasswordParams, p.Time, p.Memory, p.Threads)
+		}
+		if p.SaltLen < 8 || p.SaltLen > maxArgon2SaltLen || p.KeyLen < 16 || p.KeyLen > maxArgon2KeyLen {
+			return fmt.Errorf("%w: argon2id salt=%d key=%d", ErrInvalidPasswordParams, p.SaltLen, p.KeyLen)
+		}
+	case PasswordBcrypt:
+		if p.Cost < bcrypt.MinCost || p.Cost > bcrypt.MaxCost {
+			return fmt.Errorf("%w: bcrypt cost %d", ErrInvalidPasswordParams, p.Cost)
+		}
+	default:
+		return fmt.Errorf("%w: unknown scheme %q", ErrInvalidPasswordParams, p.Scheme)
+	}
+	return nil
+}
+
+// HashPassword hashes password with a random salt. Argon2id produces a PHC
+// string ($argon2id$v=19$m=...,t=...,p=...$salt$hash); bcrypt produces its
+// standard $2a$ modular crypt string. bcrypt rejects passwords over 72 bytes
+// with ErrPasswordTooLong.
+func (h *Hash) HashPassword(password string, params PasswordParams) (string, error) {
+	if err := params.validate(); err != nil {
+		return "", err
+	}
+
+	if params.Scheme == PasswordBcrypt {
+		encoded, err := bcrypt.GenerateFromPassword([]byte(password), params.Cost)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword reports whether password matches encoded. A mismatch is
+// (false, nil); an error means encoded could not be parsed.
+func (h *Hash) VerifyPassword(password, encoded string) (bool, error) {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		switch {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+			return false, nil
+		default:
+			return false, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+		}
+	}
+
+	params, salt, key, err := parseArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	got := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+	return subtle.ConstantTimeCompare(got, key) == 1, nil
+}
+
+// NeedsRehash reports whether encoded was produced with a different scheme
+// or cost than params. Unparseable hashes always need a rehash.
+func (h *Hash) NeedsRehash(encoded string, params PasswordParams) bool {
+	if isBcrypt(encoded) {
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || params.Scheme != PasswordBcrypt || cost != params.Cost
+	}
+
+	current, _, _, err := parseArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Scheme != PasswordArgon2id ||
+		current.Time != params.Time ||
+		current.Memory != params.Memory ||
+		current.Threads != params.Threads ||
+		current.SaltLen != params.SaltLen ||
+		current.KeyLen != params.KeyLen
+}
+
+// VerifyAndUpgradePassword verifies password and, when it matches and
+// encoded is outdated according to NeedsRehash, returns a fresh hash built
+// with params. The returned hash is empty when no upgrade is needed.
+func (h *Hash) VerifyAndUpgradePassword(password, encoded string, params PasswordParams) (bool, string, error) {
+	ok, err := h.VerifyPassword(password, encoded)
+	if err != nil || !ok {
+		return false, "", err
+	}
+
+	if !h.NeedsRehash(encoded, params) {
+		return true, "", nil
+	}
+
+	upgraded, err := h.HashPassword(password, params)
+	if err != nil {
+		return true, "", err
+	}
+	return true, upgraded, nil
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") ||
+		strings.HasPrefix(encoded, "$2b$") ||
+		strings.HasPrefix(encoded, "$2y$")
+}
+
+func parseArgon2id(encoded string) (PasswordParams, []byte, []byte, error) {
+	params := PasswordParams{Scheme: PasswordArgon2id}
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != string(PasswordArgon2id) {
+		return params, nil, nil, ErrInvalidPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported argon2 version %q", ErrInvalidPasswordHash, parts[2])
+	}
+
+	var threads uint32
+	n, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &threads)
+	if err != nil || n != 3 || threads > 255 ||
+		parts[3] != fmt.Sprintf("m=%d,t=%d,p=%d", params.Memory, params.Time, threads) {
+		return params, nil, nil, fmt.Errorf("%w: malformed parameters %q", ErrInvalidPasswordHash, parts[3])
+	}
+	params.Threads = uint8(threads)
+
+	salt, err := base64.RawStdEncoding.Strict().DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed salt", ErrInvalidPasswordHash)
+	}
+	key, err := base64.RawStdEncoding.Strict().DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("%w: malformed hash", ErrInvalidPasswordHash)
+	}
+	params.SaltLen = uint32(len(salt))
+	params.KeyLen = uint32(len(key))
+
+	if err := params.validate(); err != nil {
+		return params, nil, nil, fmt.Errorf("%w: %v", ErrInvalidPasswordHash, err)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,145 @@
+package hash
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testArgon2idParams() PasswordParams {
+	p := DefaultArgon2idParams()
+	p.Time, p.Memory, p.Threads = 1, 1024, 1
+	return p
+}
+
+func TestPassword(t *testing.T) {
+	hasher := New()
+
+	tests := []struct {
+		name   string
+		params PasswordParams
+		prefix string
+	}{
+		{"argon2id", testArgon2idParams(), "$argon2id$v=19$m=1024,t=1,p=1$"},
+		{"bcrypt", PasswordParams{Scheme: PasswordBcrypt, Cost: 4}, "$2a$04$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := hasher.HashPassword("correct horse", tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(encoded, tt.prefix) {
+				t.Errorf("HashPassword = %s; want prefix %s", encoded, tt.prefix)
+			}
+
+			again, _ := hasher.HashPassword("correct horse", tt.params)
+			if again == encoded {
+				t.Error("hashes of the same password should use different salts")
+			}
+
+			if ok, err := hasher.VerifyPassword("correct horse", encoded); err != nil || !ok {
+				t.Errorf("VerifyPassword = %v, %v; want true", ok, err)
+			}
+			if ok, err := hasher.VerifyPassword("wrong horse", encoded); err != nil || ok {
+				t.Errorf("VerifyPassword(wrong) = %v, %v; want false", ok, err)
+			}
+
+			if hasher.NeedsRehash(encoded, tt.params) {
+				t.Error("NeedsRehash should be false for matching params")
+			}
+		})
+	}
+}
+
+func TestVerifyPasswordKnownHash(t *testing.T) {
+	hasher := New()
+
+	// Test vector from the argon2 reference implementation (src/test.c).
+	encoded := "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc"
+	if ok, err := hasher.VerifyPassword("password", encoded); err != nil || !ok {
+		t.Errorf("VerifyPassword = %v, %v; want true", ok, err)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hasher := New()
+	params := testArgon2idParams()
+
+	encoded, err := hasher.HashPassword("secret", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stronger := params
+	stronger.Time = 2
+	if !hasher.NeedsRehash(encoded, stronger) {
+		t.Error("NeedsRehash should be true when time cost changes")
+	}
+	if !hasher.NeedsRehash(encoded, PasswordParams{Scheme: PasswordBcrypt, Cost: 4}) {
+		t.Error("NeedsRehash should be true when scheme changes")
+	}
+	if !hasher.NeedsRehash("not a hash", params) {
+		t.Error("NeedsRehash should be true for unparseable hashes")
+	}
+
+	bcryptHash, err := hasher.HashPassword("secret", PasswordParams{Scheme: PasswordBcrypt, Cost: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasher.NeedsRehash(bcryptHash, PasswordParams{Scheme: PasswordBcrypt, Cost: 5}) {
+		t.Error("NeedsRehash should be true when bcrypt cost changes")
+	}
+
+	ok, upgraded, err := hasher.VerifyAndUpgradePassword("secret", bcryptHash, params)
+	if err != nil || !ok || !strings.HasPrefix(upgraded, "$argon2id$") {
+		t.Fatalf("VerifyAndUpgradePassword = %v, %q, %v", ok, upgraded, err)
+	}
+	ok, again, err := hasher.VerifyAndUpgradePassword("secret", upgraded, params)
+	if err != nil || !ok || again != "" {
+		t.Errorf("VerifyAndUpgradePassword on current hash = %v, %q, %v", ok, again, err)
+	}
+	ok, again, err = hasher.VerifyAndUpgradePassword("wrong", upgraded, params)
+	if err != nil || ok || again != "" {
+		t.Errorf("VerifyAndUpgradePassword(wrong) = %v, %q, %v", ok, again, err)
+	}
+}
+
+func TestPasswordErrors(t *testing.T) {
+	hasher := New()
+
+	invalid := []PasswordParams{
+		{},
+		{Scheme: PasswordBcrypt, Cost: 3},
+		{Scheme: PasswordArgon2id, Time: 1, Memory: 4, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Scheme: PasswordArgon2id, Time: 1, Memory: 1024, Threads: 1, SaltLen: 4, KeyLen: 32},
+	}
+	for _, p := range invalid {
+		if _, err := hasher.HashPassword("x", p); !errors.Is(err, ErrInvalidPasswordParams) {
+			t.Errorf("HashPassword(%+v) error = %v; want ErrInvalidPasswordParams", p, err)
+		}
+	}
+
+	long := strings.Repeat("a", 73)
+	if _, err := hasher.HashPassword(long, PasswordParams{Scheme: PasswordBcrypt, Cost: 4}); !errors.Is(err, ErrPasswordTooLong) {
+		t.Errorf("HashPassword(long) error = %v; want ErrPasswordTooLong", err)
+	}
+
+	malformed := []string{
+		"",
+		"$argon2i$v=19$m=1024,t=1,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=16$m=1024,t=1,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=19$t=1,m=1024,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=19$m=99999999,t=1,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=19$m=1048576,t=1,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=19$m=1024,t=50,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$argon2id$v=19$m=1024,t=1,p=1$!!$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8",
+		"$2a$04$short",
+	}
+	for _, m := range malformed {
+		if _, err := hasher.VerifyPassword("password", m); !errors.Is(err, ErrInvalidPasswordHash) {
+			t.Errorf("VerifyPassword(%q) error = %v; want ErrInvalidPasswordHash", m, err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package hash
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// DefaultReplicas is the number of virtual nodes placed on the ring for each
+// node when NewRing is given a non-positive count.
+const DefaultReplicas = 160
+
+type ringPoint struct {
+	hash uint64
+	node string
+}
+
+// Ring is a consistent-hash ring with virtual nodes. Placement depends only
+// on the set of nodes, not on the order they were added, so every process
+// with the same nodes maps a key to the same node. It is safe for
+// concurrent use.
+type Ring struct {
+	mu       sync.RWMutex
+	replicas int
+	points   []ringPoint
+	nodes    map[string]struct{}
+}
+
+func (h *Hash) NewRing(replicas int, nodes ...string) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+
+	r := &Ring{replicas: replicas, nodes: make(map[string]struct{})}
+	r.Add(nodes...)
+	return r
+}
+
+func (r *Ring) Add(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			continue
+		}
+		r.nodes[node] = struct{}{}
+
+		for i := 0; i < r.replicas; i++ {
+			r.points = append(r.points, ringPoint{hash: xxhash64(node+"#"+strconv.Itoa(i), 0), node: node})
+		}
+	}
+
+	sort.Slice(r.points, func(i, j int) bool {
+		if r.points[i].hash != r.points[j].hash {
+			return r.points[i].hash < r.points[j].hash
+		}
+		return r.points[i].node < r.points[j].node
+	})
+}
+
+func (r *Ring) Remove(nodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := false
+	for _, node := range nodes {
+		if _, ok := r.nodes[node]; ok {
+			delete(r.nodes, node)
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+
+	points := r.points[:0]
+	for _, p := range r.points {
+		if _, ok := r.nodes[p.node]; ok {
+			points = append(points, p)
+		}
+	}
+	r.points = points
+}
+
+// Nodes returns the nodes on the ring in sorted order.
+func (r *Ring) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	nodes := make([]string, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+func (r *Ring) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.nodes)
+}
+
+// Get returns the node owning key, or false when the ring is empty.
+func (r *Ring) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 {
+		return "", false
+	}
+	return r.points[r.search(key)].node, true
+}
+
+// GetN returns up to n distinct nodes for key, walking the ring clockwise
+// from its owner. The first node is the same one Get returns, so the rest
+// can be used as replicas.
+func (r *Ring) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.points) == 0 || n <= 0 {
+		return nil
+	}
+	n = min(n, len(r.nodes))
+
+	nodes := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	for i, start := 0, r.search(key); len(nodes) < n; i++ {
+		node := r.points[(start+i)%len(r.points)].node
+		if _, ok := seen[node]; ok {
+			continue
+		}
+		seen[node] = struct{}{}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (r *Ring) search(key string) int {
+	sum := xxhash64(key, 0)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= sum })
+	if i == len(r.points) {
+		return 0
+	}
+	return i
+}
@@ -0,0 +1,213 @@
+package hash
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	stdhash "hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Digests maps each requested algorithm to its lowercase hex digest.
+type Digests map[Algorithm]string
+
+// Match compares expected, in any case, with the digest for alg.
+func (d Digests) Match(alg Algorithm, expected string) bool {
+	got, ok := d[alg]
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(got), []byte(strings.ToLower(expected))) == 1
+}
+
+// HashReader reads r once and computes every algorithm in algs, keeping
+// memory use constant. SHA-256 is used when algs is empty.
+func (h *Hash) HashReader(r io.Reader, algs ...Algorithm) (Digests, error) {
+	if len(algs) == 0 {
+		algs = []Algorithm{AlgSHA256}
+	}
+
+	sums := make([]stdhash.Hash, len(algs))
+	writers := make([]io.Writer, len(algs))
+	for i, alg := range algs {
+		s, err := alg.New()
+		if err != nil {
+			return nil, err
+		}
+		sums[i], writers[i] = s, s
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+
+	digests := make(Digests, len(algs))
+	for i, alg := range algs {
+		digests[alg] = hex.EncodeToString(sums[i].Sum(nil))
+	}
+	return digests, nil
+}
+
+func (h *Hash) HashFile(filePath string, algs ...Algorithm) (Digests, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return h.HashReader(bufio.NewReaderSize(file, 1<<20), algs...)
+}
+
+// ManifestEntry is one line of a sha256sum style checksum file.
+type ManifestEntry struct {
+	Digest string
+	Path   string
+}
+
+type ManifestStatus int
+
+const (
+	ManifestOK ManifestStatus = iota
+	ManifestMismatch
+	ManifestMissing
+)
+
+func (s ManifestStatus) String() string {
+	return [...]string{"OK", "FAILED", "MISSING"}[s]
+}
+
+type ManifestResult struct {
+	Path     string
+	Status   ManifestStatus
+	Expected string
+	Actual   string
+}
+
+var ErrInvalidManifest = errors.New("hash: invalid manifest line")
+
+// WriteManifest writes entries as "<digest>  <path>" lines, the format read
+// by `sha256sum -c`.
+func (h *Hash) WriteManifest(w io.Writer, entries []ManifestEntry) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		if strings.ContainsAny(e.Path, "\n\r") {
+			return fmt.Errorf("%w: path contains a newline: %q", ErrInvalidManifest, e.Path)
+		}
+
+		if _, err := fmt.Fprintf(bw, "%s  %s\n", e.Digest, e.Path); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadManifest parses sha256sum style lines in text ("digest  path") or
+// binary ("digest *path") mode. Blank lines and lines starting with # are
+// skipped. Absolute paths and paths that climb out with .. are rejected.
+func (h *Hash) ReadManifest(r io.Reader) ([]ManifestEntry, error) {
+	var entries []ManifestEntry
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		digest, path, ok := strings.Cut(text, " ")
+		if !ok || len(path) < 2 || (path[0] != ' ' && path[0] != '*') || !isHexDigest(digest) {
+			return nil, fmt.Errorf("%w %d: %q", ErrInvalidManifest, line, text)
+		}
+
+		if !isLocalPath(path[1:]) {
+			return nil, fmt.Errorf("%w %d: path escapes the root: %q", ErrInvalidManifest, line, path[1:])
+		}
+
+		entries = append(entries, ManifestEntry{Digest: strings.ToLower(digest), Path: path[1:]})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// BuildManifest hashes every regular file under root with alg. Paths are
+// relative to root, slash separated and sorted.
+func (h *Hash) BuildManifest(root string, alg Algorithm) ([]ManifestEntry, error) {
+	var entries []ManifestEntry
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		digests, err := h.HashFile(path, alg)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, ManifestEntry{Digest: digests[alg], Path: filepath.ToSlash(rel)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries, nil
+}
+
+// VerifyManifest rehashes each entry relative to root. The error is only set
+// for I/O failures other than missing files and for entries whose path is
+// absolute or leaves root; check each result's Status.
+func (h *Hash) VerifyManifest(root string, alg Algorithm, entries []ManifestEntry) ([]ManifestResult, error) {
+	results := make([]ManifestResult, 0, len(entries))
+
+	for _, e := range entries {
+		if !isLocalPath(e.Path) {
+			return nil, fmt.Errorf("%w: path escapes the root: %q", ErrInvalidManifest, e.Path)
+		}
+
+		res := ManifestResult{Path: e.Path, Expected: strings.ToLower(e.Digest)}
+
+		digests, err := h.HashFile(filepath.Join(root, filepath.FromSlash(e.Path)), alg)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			res.Status = ManifestMissing
+		case err != nil:
+			return nil, err
+		default:
+			res.Actual = digests[alg]
+			if !digests.Match(alg, e.Digest) {
+				res.Status = ManifestMismatch
+			}
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// isLocalPath reports whether the slash separated path stays within the
+// directory it is joined to.
+func isLocalPath(path string) bool {
+	return filepath.IsLocal(filepath.FromSlash(path))
+}
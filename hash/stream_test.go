@@ -0,0 +1,177 @@
+package hash
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHashReader(t *testing.T) {
+	hasher := New()
+
+	digests, err := hasher.HashReader(strings.NewReader("hello"), AlgMD5, AlgSHA256, AlgBLAKE2b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := digests[AlgMD5]; got != hasher.MD5("hello") {
+		t.Errorf("md5 = %s; want %s", got, hasher.MD5("hello"))
+	}
+	if got := digests[AlgSHA256]; got != hasher.SHA256("hello") {
+		t.Errorf("sha256 = %s; want %s", got, hasher.SHA256("hello"))
+	}
+	if got := digests[AlgBLAKE2b]; got != hasher.BLAKE2b("hello") {
+		t.Errorf("blake2b = %s; want %s", got, hasher.BLAKE2b("hello"))
+	}
+
+	if !digests.Match(AlgSHA256, strings.ToUpper(hasher.SHA256("hello"))) {
+		t.Error("Match should ignore case")
+	}
+	if digests.Match(AlgSHA1, hasher.SHA1("hello")) {
+		t.Error("Match should fail for an algorithm that was not computed")
+	}
+
+	if _, err := hasher.HashReader(strings.NewReader(""), Algorithm("crc32")); err == nil {
+		t.Error("expected error for unknown algorithm")
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	hasher := New()
+	path := filepath.Join(t.TempDir(), "data.bin")
+	data := bytes.Repeat([]byte("xutils"), 500000)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	digests, err := hasher.HashFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := digests[AlgSHA256], hasher.SHA256(string(data)); got != want {
+		t.Errorf("HashFile = %s; want %s", got, want)
+	}
+
+	if _, err := hasher.HashFile(path + ".missing"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
+
+func TestManifest(t *testing.T) {
+	hasher := New()
+	root := t.TempDir()
+
+	files := map[string]string{
+		"a.txt":         "alpha",
+		"sub/b.txt":     "bravo",
+		"sub/deep/c.md": "charlie",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := hasher.BuildManifest(root, AlgSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].Path != "a.txt" || entries[2].Path != "sub/deep/c.md" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	if entries[0].Digest != hasher.SHA256("alpha") {
+		t.Errorf("digest = %s; want %s", entries[0].Digest, hasher.SHA256("alpha"))
+	}
+
+	var buf bytes.Buffer
+	if err := hasher.WriteManifest(&buf, entries); err != nil {
+		t.Fatal(err)
+	}
+	want := hasher.SHA256("alpha") + "  a.txt\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("manifest starts with %q; want %q", buf.String(), want)
+	}
+
+	parsed, err := hasher.ReadManifest(strings.NewReader("# checksums\n\n" + buf.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != len(entries) {
+		t.Fatalf("parsed %d entries; want %d", len(parsed), len(entries))
+	}
+	for i := range parsed {
+		if parsed[i] != entries[i] {
+			t.Errorf("entry %d = %+v; want %+v", i, parsed[i], entries[i])
+		}
+	}
+
+	results, err := hasher.VerifyManifest(root, AlgSHA256, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Status != ManifestOK {
+			t.Errorf("%s: %s", r.Path, r.Status)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(root, "sub", "b.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err = hasher.VerifyManifest(root, AlgSHA256, parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []ManifestStatus{ManifestMismatch, ManifestMissing, ManifestOK}
+	for i, r := range results {
+		if r.Status != statuses[i] {
+			t.Errorf("%s: %s; want %s", r.Path, r.Status, statuses[i])
+		}
+	}
+	if results[0].Actual != hasher.SHA256("tampered") {
+		t.Errorf("actual = %s; want %s", results[0].Actual, hasher.SHA256("tampered"))
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	hasher := New()
+	sum := hasher.SHA256("x")
+
+	entries, err := hasher.ReadManifest(strings.NewReader(strings.ToUpper(sum) + " *bin/file name.exe\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Path != "bin/file name.exe" || entries[0].Digest != sum {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+
+	invalid := []string{
+		"nothex  a.txt", "0x" + sum + "  a.txt", sum, sum + " a.txt", sum + "  ",
+		sum + "  ../../etc/shadow", sum + "  /etc/shadow", sum + " *sub/../../x",
+	}
+	for _, line := range invalid {
+		if _, err := hasher.ReadManifest(strings.NewReader(line)); err == nil {
+			t.Errorf("expected error for %q", line)
+		}
+	}
+
+	escape := []ManifestEntry{{Digest: sum, Path: "../outside.txt"}}
+	if _, err := hasher.VerifyManifest(t.TempDir(), AlgSHA256, escape); !errors.Is(err, ErrInvalidManifest) {
+		t.Errorf("VerifyManifest(escape) error = %v; want ErrInvalidManifest", err)
+	}
+
+	if err := hasher.WriteManifest(&bytes.Buffer{}, []ManifestEntry{{Digest: sum, Path: "a\nb"}}); err == nil {
+		t.Error("expected error for path with newline")
+	}
+}
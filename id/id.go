@@ -0,0 +1,47 @@
+package id
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/thiagozs/go-xutils/hash"
+)
+
+var (
+	ErrInvalidUUID  = errors.New("id: invalid UUID")
+	ErrInvalidULID  = errors.New("id: invalid ULID")
+	ErrInvalidKSUID = errors.New("id: invalid KSUID")
+	ErrNoTimestamp  = errors.New("id: UUID version has no timestamp")
+)
+
+// ID generates UUIDs, ULIDs and KSUIDs from crypto/rand. Time-ordered IDs
+// from the same ID are strictly increasing, even within one clock tick or
+// when the clock steps backwards, so they sort in creation order. It is
+// safe for concurrent use.
+type ID struct {
+	hash *hash.Hash
+	rand io.Reader
+	now  func() time.Time
+
+	mu    sync.Mutex
+	uuid7 uuid7State
+	ulid  ulidState
+	ksuid ksuidState
+}
+
+func New() *ID {
+	return &ID{
+		hash: hash.New(),
+		rand: rand.Reader,
+		now:  time.Now,
+	}
+}
+
+func (i *ID) random(b []byte) {
+	if _, err := io.ReadFull(i.rand, b); err != nil {
+		panic("id: reading random bytes: " + err.Error())
+	}
+}
@@ -0,0 +1,209 @@
+package id
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestUUIDv4(t *testing.T) {
+	ids := New()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		u := ids.UUIDv4()
+		if v, err := ids.UUIDVersion(u); err != nil || v != 4 {
+			t.Fatalf("UUIDVersion(%s) = %d, %v; want 4", u, v, err)
+		}
+		if seen[u] {
+			t.Fatalf("duplicate UUID %s", u)
+		}
+		seen[u] = true
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	ids := New()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	ids.now = fixedClock(now)
+
+	previous := ""
+	for i := 0; i < 10000; i++ {
+		u := ids.UUIDv7()
+		if u <= previous {
+			t.Fatalf("UUIDv7 %s is not greater than %s", u, previous)
+		}
+		previous = u
+	}
+
+	// 10000 UUIDs in one millisecond overflow the 12-bit counter and borrow
+	// the next milliseconds.
+	if ts, _ := ids.UUIDTime(previous); !ts.After(now) {
+		t.Errorf("UUIDTime after counter overflow = %v; want after %v", ts, now)
+	}
+
+	ids.now = fixedClock(now.Add(-time.Hour))
+	if u := ids.UUIDv7(); u <= previous {
+		t.Errorf("UUIDv7 went backwards with the clock: %s <= %s", u, previous)
+	}
+
+	fresh := New()
+	fresh.now = fixedClock(now)
+	u := fresh.UUIDv7()
+	if v, err := fresh.UUIDVersion(u); err != nil || v != 7 {
+		t.Errorf("UUIDVersion(%s) = %d, %v; want 7", u, v, err)
+	}
+	if ts, err := fresh.UUIDTime(u); err != nil || !ts.Equal(now) {
+		t.Errorf("UUIDTime(%s) = %v, %v; want %v", u, ts, err, now)
+	}
+}
+
+func TestUUIDValidation(t *testing.T) {
+	ids := New()
+
+	// Examples from RFC 9562, appendix A; all were created at the same instant.
+	created := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	timed := map[string]int{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846": 1,
+		"1EC9414C-232A-6B00-B3C8-9F6BDECED846": 6,
+		"017F22E2-79B0-7CC3-98C4-DC0C0C07398F": 7,
+	}
+	for u, version := range timed {
+		if v, err := ids.UUIDVersion(u); err != nil || v != version {
+			t.Errorf("UUIDVersion(%s) = %d, %v; want %d", u, v, err, version)
+		}
+		if ts, err := ids.UUIDTime(u); err != nil || !ts.Equal(created) {
+			t.Errorf("UUIDTime(%s) = %v, %v; want %v", u, ts, err, created)
+		}
+	}
+
+	valid := map[string]int{
+		"919108f7-52d1-3320-9bac-f847db4148a8": 3,
+		"2ed6657d-e927-568b-95e1-2665a8aea6a2": 5,
+		"5c146b14-3c52-8afd-938a-375d0df1fbf6": 8,
+		"00000000-0000-0000-0000-000000000000": 0,
+		"ffffffff-ffff-ffff-ffff-ffffffffffff": 15,
+	}
+	for u, version := range valid {
+		if v, err := ids.UUIDVersion(u); err != nil || v != version {
+			t.Errorf("UUIDVersion(%s) = %d, %v; want %d", u, v, err, version)
+		}
+	}
+	if _, err := ids.UUIDTime("919108f7-52d1-3320-9bac-f847db4148a8"); err == nil {
+		t.Error("UUIDTime should fail for a version 3 UUID")
+	}
+
+	invalid := []string{
+		"",
+		"919108f752d133209bacf847db4148a8",
+		"919108f7-52d1-0320-9bac-f847db4148a8",
+		"919108f7-52d1-3320-cbac-f847db4148a8",
+		"919108f7-52d1-3320-9bac-f847db4148ag",
+		"{919108f7-52d1-3320-9bac-f847db4148a}",
+	}
+	for _, u := range invalid {
+		if ids.IsUUID(u) {
+			t.Errorf("IsUUID(%q) = true; want false", u)
+		}
+	}
+}
+
+func TestULID(t *testing.T) {
+	ids := New()
+
+	const example = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	if ts, err := ids.ULIDTime(example); err != nil || ts.UnixMilli() != 1469922850259 {
+		t.Errorf("ULIDTime(%s) = %v, %v; want 1469922850259ms", example, ts, err)
+	}
+	if hi, lo, _ := decodeULID(example); encodeULID(hi, lo) != example {
+		t.Errorf("ULID round trip = %s; want %s", encodeULID(hi, lo), example)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ids.now = fixedClock(now)
+
+	previous := ""
+	for i := 0; i < 1000; i++ {
+		u := ids.ULID()
+		if u <= previous {
+			t.Fatalf("ULID %s is not greater than %s", u, previous)
+		}
+		previous = u
+	}
+	if ts, err := ids.ULIDTime(previous); err != nil || !ts.Equal(now) {
+		t.Errorf("ULIDTime = %v, %v; want %v", ts, err, now)
+	}
+
+	for v, want := range map[string]bool{
+		"7ZZZZZZZZZZZZZZZZZZZZZZZZZ": true,
+		"01arz3ndektsv4rrffq69g5fav": true,
+		"80000000000000000000000000": false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FA":  false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU": false,
+	} {
+		if got := ids.IsULID(v); got != want {
+			t.Errorf("IsULID(%q) = %v; want %v", v, got, want)
+		}
+	}
+}
+
+func TestULIDOverflow(t *testing.T) {
+	ids := New()
+	ids.rand = bytes.NewReader(bytes.Repeat([]byte{0xff}, 20))
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ids.now = fixedClock(now)
+
+	first, second := ids.ULID(), ids.ULID()
+	if second <= first {
+		t.Fatalf("ULID %s is not greater than %s", second, first)
+	}
+	if ts, _ := ids.ULIDTime(second); !ts.Equal(now.Add(time.Millisecond)) {
+		t.Errorf("ULIDTime after overflow = %v; want %v", ts, now.Add(time.Millisecond))
+	}
+}
+
+func TestKSUID(t *testing.T) {
+	ids := New()
+
+	// Example from github.com/segmentio/ksuid.
+	const example = "0ujtsYcgvSTl8PAuAdqWYSMnLOv"
+	created := time.Unix(1507608047, 0).UTC()
+	payload, _ := hex.DecodeString("b5a1cd34b5f99d1154fb6853345c9735")
+
+	if ts, err := ids.KSUIDTime(example); err != nil || !ts.Equal(created) {
+		t.Errorf("KSUIDTime(%s) = %v, %v; want %v", example, ts, err, created)
+	}
+
+	ids.now = fixedClock(created)
+	ids.rand = bytes.NewReader(payload)
+	if got := ids.KSUID(); got != example {
+		t.Errorf("KSUID = %s; want %s", got, example)
+	}
+
+	ids.rand = bytes.NewReader(make([]byte, 16*1000))
+	previous := example
+	for i := 0; i < 1000; i++ {
+		k := ids.KSUID()
+		if len(k) != ksuidLen || k <= previous {
+			t.Fatalf("KSUID %s is not greater than %s", k, previous)
+		}
+		previous = k
+	}
+
+	for v, want := range map[string]bool{
+		"000000000000000000000000000": true,
+		"aWgEPTl1tmebfsQzFP4bxwgy80V": true,
+		"aWgEPTl1tmebfsQzFP4bxwgy80W": false,
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO":  false,
+		"0ujtsYcgvSTl8PAuAdqWYSMnLO-": false,
+	} {
+		if got := ids.IsKSUID(v); got != want {
+			t.Errorf("IsKSUID(%q) = %v; want %v", v, got, want)
+		}
+	}
+}
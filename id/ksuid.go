@@ -0,0 +1,90 @@
+package id
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ksuidLen      = 27
+	ksuidBytes    = 20
+	ksuidEpoch    = 1400000000
+	ksuidMaxValue = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+type ksuidState struct {
+	ts      uint32
+	payload [16]byte
+}
+
+// KSUID returns a 27 character Base62 KSUID: a 32-bit timestamp in seconds
+// since 2014-05-13 followed by 128 random bits. Within the same second the
+// payload is incremented instead of redrawn, so the strings sort in
+// creation order.
+func (i *ID) KSUID() string {
+	var payload [16]byte
+	i.random(payload[:])
+
+	ts := uint32(i.now().Unix() - ksuidEpoch)
+
+	i.mu.Lock()
+	st := &i.ksuid
+	if ts > st.ts {
+		st.ts, st.payload = ts, payload
+	} else if !incrementBytes(st.payload[:]) {
+		// The payload overflowed; borrow the next second.
+		st.ts++
+		st.payload = payload
+	}
+	var raw [ksuidBytes]byte
+	binary.BigEndian.PutUint32(raw[:4], st.ts)
+	copy(raw[4:], st.payload[:])
+	i.mu.Unlock()
+
+	encoded := i.hash.Base62Encode(raw[:])
+	return strings.Repeat("0", ksuidLen-len(encoded)) + encoded
+}
+
+func (i *ID) IsKSUID(v string) bool {
+	_, err := i.decodeKSUID(v)
+	return err == nil
+}
+
+// KSUIDTime returns the creation time, to the second, stored in a KSUID.
+func (i *ID) KSUIDTime(v string) (time.Time, error) {
+	raw, err := i.decodeKSUID(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(raw[:4]))+ksuidEpoch, 0).UTC(), nil
+}
+
+func (i *ID) decodeKSUID(v string) ([ksuidBytes]byte, error) {
+	var raw [ksuidBytes]byte
+	if len(v) != ksuidLen || v > ksuidMaxValue {
+		return raw, fmt.Errorf("%w: %q", ErrInvalidKSUID, v)
+	}
+
+	decoded, err := i.hash.Base62Decode(v)
+	if err != nil {
+		return raw, fmt.Errorf("%w: %v", ErrInvalidKSUID, err)
+	}
+
+	// Leading '0' digits decode to extra zero bytes; keep the low 20.
+	copy(raw[:], decoded[len(decoded)-ksuidBytes:])
+	return raw, nil
+}
+
+// incrementBytes adds one to b as a big-endian number and reports false if
+// it wrapped around to zero.
+func incrementBytes(b []byte) bool {
+	for j := len(b) - 1; j >= 0; j-- {
+		b[j]++
+		if b[j] != 0 {
+			return true
+		}
+	}
+	return false
+}
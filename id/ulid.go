@@ -0,0 +1,110 @@
+package id
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ulidLen      = 26
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// ulidState holds the last timestamp and the 80-bit random part as a 16-bit
+// high word and a 64-bit low word.
+type ulidState struct {
+	ms uint64
+	hi uint16
+	lo uint64
+}
+
+// ULID returns a 26 character Crockford Base32 ULID: a 48-bit millisecond
+// timestamp followed by 80 random bits. Within the same millisecond the
+// random part is incremented instead of redrawn, as the ULID spec's
+// monotonic mode describes, so the strings sort in creation order.
+func (i *ID) ULID() string {
+	var r [10]byte
+	i.random(r[:])
+
+	ms := uint64(i.now().UnixMilli())
+
+	i.mu.Lock()
+	st := &i.ulid
+	if ms > st.ms {
+		st.ms = ms
+		st.hi, st.lo = binary.BigEndian.Uint16(r[0:]), binary.BigEndian.Uint64(r[2:])
+	} else {
+		st.lo++
+		if st.lo == 0 {
+			st.hi++
+			if st.hi == 0 {
+				// The random part overflowed; borrow the next millisecond.
+				st.ms++
+				st.hi, st.lo = binary.BigEndian.Uint16(r[0:])>>1, binary.BigEndian.Uint64(r[2:])
+			}
+		}
+	}
+	ms, hi, lo := st.ms, st.hi, st.lo
+	i.mu.Unlock()
+
+	return encodeULID(ms<<16|uint64(hi), lo)
+}
+
+func (i *ID) IsULID(v string) bool {
+	_, _, err := decodeULID(v)
+	return err == nil
+}
+
+// ULIDTime returns the creation time stored in a ULID.
+func (i *ID) ULIDTime(v string) (time.Time, error) {
+	hi, _, err := decodeULID(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(int64(hi >> 16)).UTC(), nil
+}
+
+// encodeULID writes the 128-bit value hi:lo as 26 base32 digits; the first
+// digit only carries the top 3 bits.
+func encodeULID(hi, lo uint64) string {
+	var buf [ulidLen]byte
+	for j := 0; j < ulidLen; j++ {
+		shift := uint(5 * (ulidLen - 1 - j))
+
+		var v uint64
+		switch {
+		case shift >= 64:
+			v = hi >> (shift - 64)
+		case shift == 0:
+			v = lo
+		default:
+			v = lo>>shift | hi<<(64-shift)
+		}
+		buf[j] = ulidAlphabet[v&31]
+	}
+	return string(buf[:])
+}
+
+func decodeULID(v string) (uint64, uint64, error) {
+	if len(v) != ulidLen {
+		return 0, 0, fmt.Errorf("%w: length %d", ErrInvalidULID, len(v))
+	}
+
+	v = strings.ToUpper(v)
+	if v[0] > '7' {
+		return 0, 0, fmt.Errorf("%w: %q overflows 128 bits", ErrInvalidULID, v)
+	}
+
+	var hi, lo uint64
+	for j := 0; j < ulidLen; j++ {
+		d := strings.IndexByte(ulidAlphabet, v[j])
+		if d < 0 {
+			return 0, 0, fmt.Errorf("%w: unexpected %q at %d", ErrInvalidULID, v[j], j)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(d)
+	}
+	return hi, lo, nil
+}
@@ -0,0 +1,152 @@
+package id
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// gregorianOffset is the number of 100ns intervals between the UUID epoch
+// (1582-10-15) and the Unix epoch, used by versions 1 and 6.
+const gregorianOffset = 122192928000000000
+
+type uuid7State struct {
+	ms      uint64
+	counter uint16
+}
+
+// UUIDv4 returns a random UUID.
+func (i *ID) UUIDv4() string {
+	var u [16]byte
+	i.random(u[:])
+
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+// UUIDv7 returns a UUID that starts with the Unix time in milliseconds, so
+// it sorts by creation time. The 12 bits after the version hold a counter
+// that is seeded randomly each millisecond and incremented for every UUID
+// generated within it (RFC 9562, section 6.2, method 1).
+func (i *ID) UUIDv7() string {
+	var u [16]byte
+	i.random(u[:])
+
+	ms := uint64(i.now().UnixMilli())
+
+	i.mu.Lock()
+	st := &i.uuid7
+	if ms > st.ms {
+		st.ms = ms
+		// Leave the top bit clear so a burst has room to count up.
+		st.counter = binary.BigEndian.Uint16(u[6:]) & 0x07ff
+	} else {
+		st.counter++
+		if st.counter > 0x0fff {
+			st.ms++
+			st.counter = binary.BigEndian.Uint16(u[6:]) & 0x07ff
+		}
+	}
+	ms, counter := st.ms, st.counter
+	i.mu.Unlock()
+
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	u[6] = 0x70 | byte(counter>>8)
+	u[7] = byte(counter)
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u)
+}
+
+// IsUUID reports whether v is a UUID in the canonical 8-4-4-4-12 form, of
+// any version from 1 to 8, or the nil or max UUID.
+func (i *ID) IsUUID(v string) bool {
+	_, err := i.UUIDVersion(v)
+	return err == nil
+}
+
+// UUIDVersion returns the version of v. The nil UUID is version 0 and the
+// max UUID is version 15.
+func (i *ID) UUIDVersion(v string) (int, error) {
+	u, err := parseUUID(v)
+	if err != nil {
+		return 0, err
+	}
+
+	switch u {
+	case [16]byte{}:
+		return 0, nil
+	case [16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}:
+		return 15, nil
+	}
+
+	version := int(u[6] >> 4)
+	if version < 1 || version > 8 || u[8]&0xc0 != 0x80 {
+		return 0, fmt.Errorf("%w: unknown version %d or variant", ErrInvalidUUID, version)
+	}
+	return version, nil
+}
+
+// UUIDTime returns the creation time stored in a version 1, 6 or 7 UUID.
+func (i *ID) UUIDTime(v string) (time.Time, error) {
+	version, err := i.UUIDVersion(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	u, _ := parseUUID(v)
+
+	switch version {
+	case 1:
+		ts := uint64(binary.BigEndian.Uint16(u[6:])&0x0fff)<<48 |
+			uint64(binary.BigEndian.Uint16(u[4:]))<<32 |
+			uint64(binary.BigEndian.Uint32(u[0:]))
+		return gregorianTime(ts), nil
+	case 6:
+		ts := uint64(binary.BigEndian.Uint32(u[0:]))<<28 |
+			uint64(binary.BigEndian.Uint16(u[4:]))<<12 |
+			uint64(binary.BigEndian.Uint16(u[6:])&0x0fff)
+		return gregorianTime(ts), nil
+	case 7:
+		ms := binary.BigEndian.Uint64(u[0:8]) >> 16
+		return time.UnixMilli(int64(ms)).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: version %d", ErrNoTimestamp, version)
+	}
+}
+
+func gregorianTime(ts uint64) time.Time {
+	return time.Unix(0, (int64(ts)-gregorianOffset)*100).UTC()
+}
+
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+func parseUUID(v string) ([16]byte, error) {
+	var u [16]byte
+	if len(v) != 36 || v[8] != '-' || v[13] != '-' || v[18] != '-' || v[23] != '-' {
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, v)
+	}
+
+	compact := v[0:8] + v[9:13] + v[14:18] + v[19:23] + v[24:]
+	if _, err := hex.Decode(u[:], []byte(compact)); err != nil {
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, v)
+	}
+	return u, nil
+}
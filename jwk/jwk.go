@@ -0,0 +1,405 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	KeyTypeRSA = "RSA"
+	KeyTypeEC  = "EC"
+	KeyTypeOKP = "OKP"
+
+	CurveEd25519 = "Ed25519"
+)
+
+var (
+	ErrUnsupportedKey = errors.New("jwk: unsupported key type")
+	ErrInvalidKey     = errors.New("jwk: invalid key")
+	ErrNotPrivate     = errors.New("jwk: key has no private part")
+)
+
+type JWK struct{}
+
+func New() *JWK {
+	return &JWK{}
+}
+
+// Key is a JSON Web Key (RFC 7517) holding an RSA, EC or Ed25519 (OKP,
+// RFC 8037) key. Binary members are unpadded base64url, as the RFC requires.
+type Key struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA public
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP public
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+
+	// Private exponent (RSA), scalar (EC) or seed (OKP)
+	D string `json:"d,omitempty"`
+
+	// RSA private CRT values
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	DP string `json:"dp,omitempty"`
+	DQ string `json:"dq,omitempty"`
+	QI string `json:"qi,omitempty"`
+}
+
+// FromPublicKey converts an *rsa.PublicKey, *ecdsa.PublicKey or
+// ed25519.PublicKey. Kid is set to the RFC 7638 thumbprint.
+func (j *JWK) FromPublicKey(pub crypto.PublicKey) (*Key, error) {
+	var k *Key
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		k = &Key{
+			Kty: KeyTypeRSA,
+			N:   encodeBig(pub.N),
+			E:   encodeBig(big.NewInt(int64(pub.E))),
+		}
+	case *ecdsa.PublicKey:
+		crv, size, err := curveName(pub.Curve)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := pub.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		k = &Key{
+			Kty: KeyTypeEC,
+			Crv: crv,
+			X:   encode(raw[1 : 1+size]),
+			Y:   encode(raw[1+size:]),
+		}
+	case ed25519.PublicKey:
+		if len(pub) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+
+		k = &Key{
+			Kty: KeyTypeOKP,
+			Crv: CurveEd25519,
+			X:   encode(pub),
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+
+	kid, err := k.Thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	k.Kid = kid
+
+	return k, nil
+}
+
+// FromPrivateKey converts an *rsa.PrivateKey, *ecdsa.PrivateKey or
+// ed25519.PrivateKey. Kid is the thumbprint of the public part, so both
+// halves share it.
+func (j *JWK) FromPrivateKey(priv crypto.PrivateKey) (*Key, error) {
+	switch priv := priv.(type) {
+	case *rsa.PrivateKey:
+		if len(priv.Primes) != 2 {
+			return nil, fmt.Errorf("%w: multi-prime RSA", ErrUnsupportedKey)
+		}
+
+		k, err := j.FromPublicKey(&priv.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		priv.Precompute()
+		k.D = encodeBig(priv.D)
+		k.P = encodeBig(priv.Primes[0])
+		k.Q = encodeBig(priv.Primes[1])
+		k.DP = encodeBig(priv.Precomputed.Dp)
+		k.DQ = encodeBig(priv.Precomputed.Dq)
+		k.QI = encodeBig(priv.Precomputed.Qinv)
+		return k, nil
+	case *ecdsa.PrivateKey:
+		k, err := j.FromPublicKey(&priv.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := priv.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		k.D = encode(d)
+		return k, nil
+	case ed25519.PrivateKey:
+		if len(priv) != ed25519.PrivateKeySize {
+			return nil, ErrInvalidKey
+		}
+
+		k, err := j.FromPublicKey(priv.Public())
+		if err != nil {
+			return nil, err
+		}
+
+		k.D = encode(priv.Seed())
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, priv)
+}
+
+// Parse decodes a single JWK from JSON and validates its key material.
+func (j *JWK) Parse(data []byte) (*Key, error) {
+	var k Key
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, err
+	}
+
+	if _, err := k.PublicKey(); err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+func (k *Key) IsPrivate() bool {
+	return k.D != ""
+}
+
+// Public returns a copy of k without private members.
+func (k *Key) Public() *Key {
+	return &Key{
+		Kty: k.Kty,
+		Kid: k.Kid,
+		Use: k.Use,
+		Alg: k.Alg,
+		N:   k.N,
+		E:   k.E,
+		Crv: k.Crv,
+		X:   k.X,
+		Y:   k.Y,
+	}
+}
+
+// Thumbprint computes the RFC 7638 SHA-256 thumbprint as unpadded base64url.
+func (k *Key) Thumbprint() (string, error) {
+	// The members must be in lexicographic order with no whitespace.
+	var canonical string
+	switch k.Kty {
+	case KeyTypeRSA:
+		if k.N == "" || k.E == "" {
+			return "", ErrInvalidKey
+		}
+		canonical = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, k.E, k.Kty, k.N)
+	case KeyTypeEC:
+		if k.Crv == "" || k.X == "" || k.Y == "" {
+			return "", ErrInvalidKey
+		}
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, k.Crv, k.Kty, k.X, k.Y)
+	case KeyTypeOKP:
+		if k.Crv == "" || k.X == "" {
+			return "", ErrInvalidKey
+		}
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, k.Crv, k.Kty, k.X)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedKey, k.Kty)
+	}
+
+	sum := sha256.Sum256([]byte(canonical))
+	return encode(sum[:]), nil
+}
+
+// PublicKey returns the *rsa.PublicKey, *ecdsa.PublicKey or
+// ed25519.PublicKey held by k.
+func (k *Key) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case KeyTypeRSA:
+		n, err := decodeBig(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBig(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: rsa exponent", ErrInvalidKey)
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case KeyTypeEC:
+		curve, size, err := curveByName(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := decodeFixed(k.X, size)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeFixed(k.Y, size)
+		if err != nil {
+			return nil, err
+		}
+
+		raw := append(append([]byte{4}, x...), y...)
+		pub, err := ecdsa.ParseUncompressedPublicKey(curve, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+
+		return pub, nil
+	case KeyTypeOKP:
+		if k.Crv != CurveEd25519 {
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, k.Crv)
+		}
+
+		x, err := decodeFixed(k.X, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, err
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedKey, k.Kty)
+}
+
+// PrivateKey returns the *rsa.PrivateKey, *ecdsa.PrivateKey or
+// ed25519.PrivateKey held by k.
+func (k *Key) PrivateKey() (crypto.PrivateKey, error) {
+	if !k.IsPrivate() {
+		return nil, ErrNotPrivate
+	}
+
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		d, err := decodeBig(k.D)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := decodeBig(k.P)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := decodeBig(k.Q)
+		if err != nil {
+			return nil, err
+		}
+
+		priv := &rsa.PrivateKey{PublicKey: *pub, D: d, Primes: []*big.Int{p, q}}
+		if err := priv.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+
+		priv.Precompute()
+		return priv, nil
+	case *ecdsa.PublicKey:
+		_, size, _ := curveByName(k.Crv)
+		d, err := decodeFixed(k.D, size)
+		if err != nil {
+			return nil, err
+		}
+
+		priv, err := ecdsa.ParseRawPrivateKey(pub.Curve, d)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+		}
+
+		if !priv.PublicKey.Equal(pub) {
+			return nil, fmt.Errorf("%w: private key does not match x and y", ErrInvalidKey)
+		}
+
+		return priv, nil
+	case ed25519.PublicKey:
+		seed, err := decodeFixed(k.D, ed25519.SeedSize)
+		if err != nil {
+			return nil, err
+		}
+
+		priv := ed25519.NewKeyFromSeed(seed)
+		if !pub.Equal(priv.Public()) {
+			return nil, fmt.Errorf("%w: private key does not match x", ErrInvalidKey)
+		}
+
+		return priv, nil
+	}
+
+	return nil, ErrUnsupportedKey
+}
+
+func curveName(c elliptic.Curve) (string, int, error) {
+	switch c {
+	case elliptic.P256():
+		return "P-256", 32, nil
+	case elliptic.P384():
+		return "P-384", 48, nil
+	case elliptic.P521():
+		return "P-521", 66, nil
+	}
+	return "", 0, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, c.Params().Name)
+}
+
+func curveByName(name string) (elliptic.Curve, int, error) {
+	switch name {
+	case "P-256":
+		return elliptic.P256(), 32, nil
+	case "P-384":
+		return elliptic.P384(), 48, nil
+	case "P-521":
+		return elliptic.P521(), 66, nil
+	}
+	return nil, 0, fmt.Errorf("%w: curve %q", ErrUnsupportedKey, name)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func encodeBig(n *big.Int) string {
+	return encode(n.Bytes())
+}
+
+func decodeBig(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("%w: bad integer encoding", ErrInvalidKey)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeFixed(s string, size int) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != size {
+		return nil, fmt.Errorf("%w: bad coordinate encoding", ErrInvalidKey)
+	}
+	return b, nil
+}
@@ -0,0 +1,128 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xrsa "github.com/thiagozs/go-xutils/rsa"
+)
+
+var testKeys = xrsa.NewKeyPool()
+
+func TestThumbprintRFC7638(t *testing.T) {
+	k := &Key{
+		Kty: KeyTypeRSA,
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+	}
+
+	tp, err := k.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", tp)
+}
+
+func TestRSARoundTrip(t *testing.T) {
+	j := New()
+	priv, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+
+	k, err := j.FromPrivateKey(priv)
+	require.NoError(t, err)
+	assert.True(t, k.IsPrivate())
+	assert.NotEmpty(t, k.Kid)
+
+	data, err := json.Marshal(k)
+	require.NoError(t, err)
+
+	parsed, err := j.Parse(data)
+	require.NoError(t, err)
+
+	got, err := parsed.PrivateKey()
+	require.NoError(t, err)
+	assert.True(t, priv.Equal(got))
+
+	pubKey, err := j.FromPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(t, k.Kid, pubKey.Kid)
+	assert.False(t, pubKey.IsPrivate())
+
+	_, err = pubKey.PrivateKey()
+	assert.ErrorIs(t, err, ErrNotPrivate)
+}
+
+func TestECRoundTrip(t *testing.T) {
+	j := New()
+
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		k, err := j.FromPrivateKey(priv)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(k)
+		require.NoError(t, err)
+
+		parsed, err := j.Parse(data)
+		require.NoError(t, err)
+
+		got, err := parsed.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, priv.Equal(got), curve.Params().Name)
+
+		pub, err := parsed.Public().PublicKey()
+		require.NoError(t, err)
+		assert.True(t, priv.PublicKey.Equal(pub))
+	}
+}
+
+func TestOKPRoundTrip(t *testing.T) {
+	j := New()
+
+	// RFC 8037 appendix A.1 and A.3.
+	doc := `{"kty":"OKP","crv":"Ed25519",` +
+		`"d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A",` +
+		`"x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`
+
+	k, err := j.Parse([]byte(doc))
+	require.NoError(t, err)
+
+	tp, err := k.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", tp)
+
+	priv, err := k.PrivateKey()
+	require.NoError(t, err)
+
+	back, err := j.FromPrivateKey(priv)
+	require.NoError(t, err)
+	assert.Equal(t, k.D, back.D)
+	assert.Equal(t, k.X, back.X)
+	assert.Equal(t, tp, back.Kid)
+
+	mismatch := *k
+	mismatch.D = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	_, err = mismatch.PrivateKey()
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestParseInvalid(t *testing.T) {
+	j := New()
+
+	tests := map[string]string{
+		"unknown kty": `{"kty":"oct","k":"AAAA"}`,
+		"bad curve":   `{"kty":"EC","crv":"P-192","x":"AA","y":"AA"}`,
+		"short x":     `{"kty":"EC","crv":"P-256","x":"AA","y":"AA"}`,
+		"bad e":       `{"kty":"RSA","n":"AQAB","e":"!!"}`,
+	}
+
+	for name, doc := range tests {
+		_, err := j.Parse([]byte(doc))
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,147 @@
+package jwk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Set is a JSON Web Key Set. It is safe for concurrent use and serves its
+// public keys over HTTP, so it can back a /.well-known/jwks.json endpoint.
+type Set struct {
+	mu   sync.RWMutex
+	keys []*Key
+}
+
+type setJSON struct {
+	Keys []*Key `json:"keys"`
+}
+
+// NewSet copies keys into a new set, dropping nil keys as Add does.
+func (j *JWK) NewSet(keys ...*Key) *Set {
+	s := &Set{keys: make([]*Key, 0, len(keys))}
+	for _, k := range keys {
+		if k != nil {
+			s.keys = append(s.keys, k)
+		}
+	}
+	return s
+}
+
+// ParseSet decodes a JWKS document, validating every key in it. Keys of a
+// type or curve this package does not support are skipped, as RFC 7517
+// section 5 recommends; malformed keys of a supported type are an error.
+func (j *JWK) ParseSet(data []byte) (*Set, error) {
+	var doc setJSON
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	keys := make([]*Key, 0, len(doc.Keys))
+	for i, k := range doc.Keys {
+		if k == nil {
+			return nil, fmt.Errorf("%w: null entry at index %d", ErrInvalidKey, i)
+		}
+		if _, err := k.PublicKey(); err != nil {
+			if errors.Is(err, ErrUnsupportedKey) {
+				continue
+			}
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return &Set{keys: keys}, nil
+}
+
+// Add inserts k, replacing any key with the same kid. A nil k is ignored.
+func (s *Set) Add(k *Key) {
+	if k == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, existing := range s.keys {
+		if existing.Kid == k.Kid {
+			s.keys[i] = k
+			return
+		}
+	}
+	s.keys = append(s.keys, k)
+}
+
+func (s *Set) Remove(kid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, k := range s.keys {
+		if k.Kid == kid {
+			s.keys = append(s.keys[:i], s.keys[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Set) Lookup(kid string) (*Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, k := range s.keys {
+		if k.Kid == kid {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+func (s *Set) Keys() []*Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]*Key(nil), s.keys...)
+}
+
+func (s *Set) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.keys)
+}
+
+func (s *Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(setJSON{Keys: s.Keys()})
+}
+
+// Public returns a set with the private members stripped from every key.
+func (s *Set) Public() *Set {
+	keys := s.Keys()
+	public := make([]*Key, len(keys))
+	for i, k := range keys {
+		public[i] = k.Public()
+	}
+	return &Set{keys: public}
+}
+
+// ServeHTTP writes the public part of the set as application/jwk-set+json.
+// Private members are never served.
+func (s *Set) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := json.Marshal(s.Public())
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,114 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	j := New()
+
+	rsaKey, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	k1, err := j.FromPrivateKey(rsaKey)
+	require.NoError(t, err)
+	k1.Use, k1.Alg = "sig", "RS256"
+
+	k2, err := j.FromPublicKey(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	set := j.NewSet(k1)
+	set.Add(k2)
+	set.Add(k2)
+	assert.Equal(t, 2, set.Len())
+
+	found, ok := set.Lookup(k1.Kid)
+	require.True(t, ok)
+	assert.Equal(t, "RS256", found.Alg)
+
+	_, ok = set.Lookup("missing")
+	assert.False(t, ok)
+
+	srv := httptest.NewServer(set)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/jwk-set+json", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), `"d"`)
+
+	served, err := j.ParseSet(body)
+	require.NoError(t, err)
+	assert.Equal(t, 2, served.Len())
+
+	pubKey, ok := served.Lookup(k1.Kid)
+	require.True(t, ok)
+	pub, err := pubKey.PublicKey()
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(pub))
+
+	post, err := http.Post(srv.URL, "application/json", nil)
+	require.NoError(t, err)
+	post.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, post.StatusCode)
+
+	set.Remove(k1.Kid)
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), k1.Kid)
+}
+
+func TestSetNilKeys(t *testing.T) {
+	j := New()
+
+	_, err := j.ParseSet([]byte(`{"keys":[null]}`))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	set := j.NewSet(nil)
+	set.Add(nil)
+	assert.Equal(t, 0, set.Len())
+	_, ok := set.Lookup("x")
+	assert.False(t, ok)
+
+	// The set must not write into the caller's slice.
+	k1, k2 := &Key{Kid: "1"}, &Key{Kid: "2"}
+	keys := []*Key{k1, k2}
+	set = j.NewSet(keys...)
+	set.Remove("1")
+	assert.Equal(t, []*Key{k1, k2}, keys)
+}
+
+func TestParseSetUnsupportedKeys(t *testing.T) {
+	j := New()
+
+	set, err := j.ParseSet([]byte(`{"keys":[
+		{"kty":"oct","k":"c2VjcmV0"},
+		{"kty":"OKP","crv":"X25519","x":"hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo"},
+		{"kty":"OKP","crv":"Ed25519","kid":"ed","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, set.Len())
+	_, ok := set.Lookup("ed")
+	assert.True(t, ok)
+
+	_, err = j.ParseSet([]byte(`{"keys":[{"kty":"OKP","crv":"Ed25519","x":"c2hvcnQ"}]}`))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
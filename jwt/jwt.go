@@ -0,0 +1,396 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thiagozs/go-xutils/jwk"
+	xrsa "github.com/thiagozs/go-xutils/rsa"
+)
+
+type Algorithm string
+
+const (
+	RS256 Algorithm = "RS256"
+	PS256 Algorithm = "PS256"
+	HS256 Algorithm = "HS256"
+	EdDSA Algorithm = "EdDSA"
+)
+
+// minHMACKeySize follows RFC 7518 section 3.2: the key must be at least as
+// long as the hash output.
+const minHMACKeySize = sha256.Size
+
+var (
+	ErrMalformed         = errors.New("jwt: malformed token")
+	ErrUnsupportedAlg    = errors.New("jwt: unsupported algorithm")
+	ErrInvalidKey        = errors.New("jwt: key does not match algorithm")
+	ErrInvalidSignature  = errors.New("jwt: invalid signature")
+	ErrExpired           = errors.New("jwt: token is expired")
+	ErrNotYetValid       = errors.New("jwt: token is not valid yet")
+	ErrMissingExpiration = errors.New("jwt: token has no expiration")
+	ErrInvalidIssuer     = errors.New("jwt: invalid issuer")
+	ErrInvalidAudience   = errors.New("jwt: invalid audience")
+	ErrKeyNotFound       = errors.New("jwt: key not found")
+	ErrHMACKeyTooShort   = errors.New("jwt: hmac key shorter than 32 bytes")
+)
+
+type JWT struct{}
+
+func New() *JWT {
+	return &JWT{}
+}
+
+type Header struct {
+	Alg Algorithm `json:"alg"`
+	Typ string    `json:"typ,omitempty"`
+	Kid string    `json:"kid,omitempty"`
+}
+
+// SigningKey pairs a key with the algorithm it signs for. Key may be:
+//   - RS256, PS256: *rsa.PrivateKey or a PEM string accepted by rsa.RSAPem
+//   - HS256: []byte secret of at least 32 bytes; strings are rejected so a
+//     PEM or SSH public key can never be mistaken for a secret
+//   - EdDSA: ed25519.PrivateKey
+type SigningKey struct {
+	Alg Algorithm
+	Kid string
+	Key any
+}
+
+// KeyResolver returns the verification key for a token. Returned keys follow
+// SigningKey, using the public halves (*rsa.PublicKey, ed25519.PublicKey or a
+// PEM public key); a *jwk.Key is also accepted.
+type KeyResolver interface {
+	ResolveKey(kid string, alg Algorithm) (any, error)
+}
+
+type KeyResolverFunc func(kid string, alg Algorithm) (any, error)
+
+func (f KeyResolverFunc) ResolveKey(kid string, alg Algorithm) (any, error) {
+	return f(kid, alg)
+}
+
+// StaticKey resolves every token to the same key.
+func StaticKey(key any) KeyResolver {
+	return KeyResolverFunc(func(string, Algorithm) (any, error) {
+		return key, nil
+	})
+}
+
+// SetResolver resolves keys by kid from a JWK Set.
+func SetResolver(set *jwk.Set) KeyResolver {
+	return KeyResolverFunc(func(kid string, _ Algorithm) (any, error) {
+		k, ok := set.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+		}
+		return k, nil
+	})
+}
+
+// Validation configures the standard claim checks done by Parse.
+type Validation struct {
+	// Algorithms restricts accepted algorithms; empty accepts all supported.
+	Algorithms []Algorithm
+	// Issuer and Audience are checked when not empty.
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew on exp and nbf.
+	Leeway time.Duration
+	// RequireExpiration rejects tokens without exp.
+	RequireExpiration bool
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Sign serializes claims as a compact JWS.
+func (j *JWT) Sign(claims any, key SigningKey) (string, error) {
+	header, err := json.Marshal(Header{Alg: key.Alg, Typ: "JWT", Kid: key.Kid})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encode(header) + "." + encode(payload)
+	sig, err := sign(key.Alg, key.Key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + encode(sig), nil
+}
+
+// Parse verifies token with the key from resolver, validates the registered
+// claims and decodes the payload into claims, which may be nil.
+func (j *JWT) Parse(token string, resolver KeyResolver, v Validation, claims any) (*Header, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	rawHeader, err := decode(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	var header Header
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return nil, ErrMalformed
+	}
+
+	if len(v.Algorithms) > 0 && !containsAlg(v.Algorithms, header.Alg) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, header.Alg)
+	}
+
+	sig, err := decode(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	key, err := resolver.ResolveKey(header.Kid, header.Alg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verify(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	payload, err := decode(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	var registered Claims
+	if err := json.Unmarshal(payload, &registered); err != nil {
+		return nil, ErrMalformed
+	}
+
+	if err := v.validate(registered); err != nil {
+		return nil, err
+	}
+
+	if claims != nil {
+		if err := json.Unmarshal(payload, claims); err != nil {
+			return nil, err
+		}
+	}
+
+	return &header, nil
+}
+
+func (v Validation) validate(c Claims) error {
+	now := time.Now()
+	if v.Now != nil {
+		now = v.Now()
+	}
+
+	if c.ExpiresAt == nil {
+		if v.RequireExpiration {
+			return ErrMissingExpiration
+		}
+	} else if !now.Before(c.ExpiresAt.Add(v.Leeway)) {
+		return ErrExpired
+	}
+
+	if c.NotBefore != nil && now.Add(v.Leeway).Before(c.NotBefore.Time) {
+		return ErrNotYetValid
+	}
+
+	if v.Issuer != "" && c.Issuer != v.Issuer {
+		return ErrInvalidIssuer
+	}
+
+	if v.Audience != "" && !c.Audience.Contains(v.Audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func sign(alg Algorithm, key any, input []byte) ([]byte, error) {
+	if err := checkKeyFamily(alg, key); err != nil {
+		return nil, err
+	}
+
+	switch alg {
+	case RS256, PS256:
+		priv, err := rsaPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		digest := sha256.Sum256(input)
+		if alg == RS256 {
+			return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest[:])
+		}
+		return rsa.SignPSS(rand.Reader, priv, crypto.SHA256, digest[:],
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case HS256:
+		secret, err := hmacKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case EdDSA:
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok || len(priv) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("%w: %s needs ed25519.PrivateKey, got %T", ErrInvalidKey, alg, key)
+		}
+		return ed25519.Sign(priv, input), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+}
+
+func verify(alg Algorithm, key any, input, sig []byte) error {
+	if k, ok := key.(*jwk.Key); ok {
+		pub, err := k.PublicKey()
+		if err != nil {
+			return err
+		}
+		key = pub
+	}
+
+	if err := checkKeyFamily(alg, key); err != nil {
+		return err
+	}
+
+	switch alg {
+	case RS256, PS256:
+		pub, err := rsaPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		digest := sha256.Sum256(input)
+		if alg == RS256 {
+			err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig)
+		} else {
+			err = rsa.VerifyPSS(pub, crypto.SHA256, digest[:], sig,
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	case HS256:
+		secret, err := hmacKey(key)
+		if err != nil {
+			return err
+		}
+
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(input)
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case EdDSA:
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok || len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w: %s needs ed25519.PublicKey, got %T", ErrInvalidKey, alg, key)
+		}
+		if !ed25519.Verify(pub, input, sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+}
+
+func rsaPrivateKey(key any) (*rsa.PrivateKey, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case string:
+		return xrsa.NewPem().RSAParsePrivateKeyFromPem(k)
+	}
+	return nil, fmt.Errorf("%w: rsa private key expected, got %T", ErrInvalidKey, key)
+}
+
+func rsaPublicKey(key any) (*rsa.PublicKey, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k, nil
+	case string:
+		return xrsa.NewPem().RSAParsePublicKeyFromPem(k)
+	}
+	return nil, fmt.Errorf("%w: rsa public key expected, got %T", ErrInvalidKey, key)
+}
+
+// keyAlgorithms returns the algorithm family a key type belongs to. Each
+// type maps to exactly one family, so a key resolved for RSA can never be
+// reused as an HMAC secret or the other way round (algorithm confusion).
+func keyAlgorithms(key any) []Algorithm {
+	switch key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey, string:
+		return []Algorithm{RS256, PS256}
+	case []byte:
+		return []Algorithm{HS256}
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return []Algorithm{EdDSA}
+	}
+	return nil
+}
+
+func checkKeyFamily(alg Algorithm, key any) error {
+	switch alg {
+	case RS256, PS256, HS256, EdDSA:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedAlg, alg)
+	}
+
+	if !containsAlg(keyAlgorithms(key), alg) {
+		return fmt.Errorf("%w: %T cannot be used with %s", ErrInvalidKey, key, alg)
+	}
+	return nil
+}
+
+func hmacKey(key any) ([]byte, error) {
+	secret, ok := key.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: hmac secret must be []byte, got %T", ErrInvalidKey, key)
+	}
+
+	if len(secret) < minHMACKeySize {
+		return nil, ErrHMACKeyTooShort
+	}
+	return secret, nil
+}
+
+func containsAlg(algs []Algorithm, alg Algorithm) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
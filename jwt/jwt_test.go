@@ -0,0 +1,244 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thiagozs/go-xutils/jwk"
+	xrsa "github.com/thiagozs/go-xutils/rsa"
+)
+
+var (
+	hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+	testKeys   = xrsa.NewKeyPool()
+)
+
+func testKeyPair(t *testing.T) (*rsa.PrivateKey, *rsa.PublicKey) {
+	t.Helper()
+	priv, err := testKeys.Key(2048, 0)
+	require.NoError(t, err)
+	return priv, &priv.PublicKey
+}
+
+type appClaims struct {
+	Claims
+	Role string `json:"role"`
+}
+
+func TestRFC7515HS256(t *testing.T) {
+	token := "eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9" +
+		".eyJpc3MiOiJqb2UiLA0KICJleHAiOjEzMDA4MTkzODAsDQogImh0dHA6Ly9leGFtcGxlLmNvbS9pc19yb290Ijp0cnVlfQ" +
+		".dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	key, err := base64.RawURLEncoding.DecodeString(
+		"AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow")
+	require.NoError(t, err)
+
+	j := New()
+	before := Validation{Issuer: "joe", Now: func() time.Time { return time.Unix(1300819000, 0) }}
+
+	var claims Claims
+	header, err := j.Parse(token, StaticKey(key), before, &claims)
+	require.NoError(t, err)
+	assert.Equal(t, HS256, header.Alg)
+	assert.Equal(t, "joe", claims.Issuer)
+
+	_, err = j.Parse(token, StaticKey(key), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestSignParse(t *testing.T) {
+	j := New()
+	pem := xrsa.NewPem()
+	priv, pub := testKeyPair(t)
+	privPem := pem.RSAExportPrivateKeyAsPem(priv)
+	pubPem, err := pem.RSAExportPublicKeyAsPem(pub)
+	require.NoError(t, err)
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		alg    Algorithm
+		sign   any
+		verify any
+	}{
+		{"RS256 key", RS256, priv, pub},
+		{"RS256 pem", RS256, privPem, pubPem},
+		{"PS256", PS256, privPem, pub},
+		{"HS256", HS256, hmacSecret, hmacSecret},
+		{"EdDSA", EdDSA, edPriv, edPub},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := appClaims{
+				Claims: Claims{
+					Issuer:    "xutils",
+					Audience:  Audience{"partners"},
+					ExpiresAt: NewNumericDate(time.Now().Add(time.Minute)),
+				},
+				Role: "admin",
+			}
+
+			token, err := j.Sign(in, SigningKey{Alg: tt.alg, Kid: "k1", Key: tt.sign})
+			require.NoError(t, err)
+
+			var out appClaims
+			header, err := j.Parse(token, StaticKey(tt.verify), Validation{
+				Issuer:            "xutils",
+				Audience:          "partners",
+				RequireExpiration: true,
+			}, &out)
+			require.NoError(t, err)
+			assert.Equal(t, "k1", header.Kid)
+			assert.Equal(t, "admin", out.Role)
+
+			tampered := token[:len(token)-4] + "AAAA"
+			_, err = j.Parse(tampered, StaticKey(tt.verify), Validation{}, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	j := New()
+	now := time.Unix(1700000000, 0)
+	key := SigningKey{Alg: HS256, Key: hmacSecret}
+
+	tokenFor := func(c Claims) string {
+		token, err := j.Sign(c, key)
+		require.NoError(t, err)
+		return token
+	}
+
+	at := func(d time.Duration) *NumericDate { return NewNumericDate(now.Add(d)) }
+	v := Validation{Now: func() time.Time { return now }}
+
+	tests := []struct {
+		name   string
+		claims Claims
+		v      Validation
+		err    error
+	}{
+		{"valid", Claims{ExpiresAt: at(time.Minute)}, v, nil},
+		{"expired", Claims{ExpiresAt: at(-time.Second)}, v, ErrExpired},
+		{"expired within leeway", Claims{ExpiresAt: at(-time.Second)}, Validation{Now: v.Now, Leeway: 5 * time.Second}, nil},
+		{"not yet valid", Claims{NotBefore: at(time.Minute)}, v, ErrNotYetValid},
+		{"nbf within leeway", Claims{NotBefore: at(3 * time.Second)}, Validation{Now: v.Now, Leeway: 5 * time.Second}, nil},
+		{"missing exp", Claims{}, Validation{Now: v.Now, RequireExpiration: true}, ErrMissingExpiration},
+		{"wrong issuer", Claims{Issuer: "evil"}, Validation{Now: v.Now, Issuer: "xutils"}, ErrInvalidIssuer},
+		{"audience list", Claims{Audience: Audience{"a", "b"}}, Validation{Now: v.Now, Audience: "b"}, nil},
+		{"wrong audience", Claims{Audience: Audience{"a"}}, Validation{Now: v.Now, Audience: "b"}, ErrInvalidAudience},
+		{"alg not allowed", Claims{}, Validation{Now: v.Now, Algorithms: []Algorithm{RS256}}, ErrUnsupportedAlg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := j.Parse(tokenFor(tt.claims), StaticKey(hmacSecret), tt.v, nil)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestKeyResolution(t *testing.T) {
+	j := New()
+	priv, pub := testKeyPair(t)
+
+	key, err := jwk.New().FromPublicKey(pub)
+	require.NoError(t, err)
+	set := jwk.New().NewSet(key)
+
+	token, err := j.Sign(Claims{Subject: "42"}, SigningKey{Alg: RS256, Kid: key.Kid, Key: priv})
+	require.NoError(t, err)
+
+	var claims Claims
+	_, err = j.Parse(token, SetResolver(set), Validation{}, &claims)
+	require.NoError(t, err)
+	assert.Equal(t, "42", claims.Subject)
+
+	unknown, err := j.Sign(Claims{}, SigningKey{Alg: RS256, Kid: "other", Key: priv})
+	require.NoError(t, err)
+	_, err = j.Parse(unknown, SetResolver(set), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestAlgorithmConfusion(t *testing.T) {
+	j := New()
+	pem := xrsa.NewPem()
+	_, pub := testKeyPair(t)
+	pubPem, err := pem.RSAExportPublicKeyAsPem(pub)
+	require.NoError(t, err)
+
+	// An attacker signs with HS256 using the public key as secret.
+	forged, err := j.Sign(Claims{}, SigningKey{Alg: HS256, Key: []byte(pubPem)})
+	require.NoError(t, err)
+
+	_, err = j.Parse(forged, StaticKey(pubPem), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = j.Parse(forged, StaticKey(pub), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = j.Sign(Claims{}, SigningKey{Alg: HS256, Key: []byte("short")})
+	assert.ErrorIs(t, err, ErrHMACKeyTooShort)
+
+	_, err = j.Sign(Claims{}, SigningKey{Alg: HS256, Key: string(hmacSecret)})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = j.Parse("a.b", StaticKey(hmacSecret), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	none := "eyJhbGciOiJub25lIn0.e30."
+	_, err = j.Parse(none, StaticKey(hmacSecret), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedAlg)
+}
+
+func TestAlgorithmConfusionSSHKey(t *testing.T) {
+	j := New()
+	pem := xrsa.NewPem()
+	priv, pub := testKeyPair(t)
+	sshPub, err := pem.RSAExportPublicKeyAsSSH(pub)
+	require.NoError(t, err)
+
+	// The SSH form of the public key is accepted for RS256, so it must not
+	// verify an HS256 token signed with the same string as the secret.
+	forged, err := j.Sign(Claims{}, SigningKey{Alg: HS256, Key: []byte(sshPub)})
+	require.NoError(t, err)
+
+	_, err = j.Parse(forged, StaticKey(sshPub), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = j.Parse(forged, StaticKey([]byte(sshPub)), Validation{Algorithms: []Algorithm{RS256}}, nil)
+	assert.ErrorIs(t, err, ErrUnsupportedAlg)
+
+	// An HMAC secret cannot verify RSA or EdDSA tokens either.
+	signed, err := j.Sign(Claims{}, SigningKey{Alg: RS256, Key: priv})
+	require.NoError(t, err)
+
+	_, err = j.Parse(signed, StaticKey(sshPub), Validation{}, nil)
+	assert.NoError(t, err)
+
+	_, err = j.Parse(signed, StaticKey(hmacSecret), Validation{}, nil)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = j.Sign(Claims{}, SigningKey{Alg: EdDSA, Key: hmacSecret})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestNumericDateFraction(t *testing.T) {
+	var d NumericDate
+	require.NoError(t, json.Unmarshal([]byte("1300819380.75"), &d))
+	assert.True(t, d.Equal(time.Unix(1300819380, 0)))
+}
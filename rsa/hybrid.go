@@ -0,0 +1,96 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/thiagozs/go-xutils/aes"
+)
+
+// Hybrid envelope layout: version(1) | len(wrappedKey)(2) | wrappedKey | payload.
+// wrappedKey is a random AES-256 key encrypted with RSA-OAEP-SHA256 and
+// payload is the message sealed with AES-GCM, authenticating the header too.
+const (
+	hybridVersion byte = 1
+	hybridKeySize      = 32
+)
+
+var (
+	hybridLabel = []byte("xutils-rsa-aes-gcm")
+
+	ErrInvalidEnvelope = errors.New("rsa: invalid hybrid envelope")
+)
+
+// Seal encrypts a message of any size for the holder of the private key.
+func (pub *RSAPublicKey) Seal(message string) (string, error) {
+	publicKey, err := pub.parse()
+	if err != nil {
+		return "", err
+	}
+
+	dataKey := make([]byte, hybridKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, publicKey, dataKey, hybridLabel)
+	if err != nil {
+		return "", err
+	}
+
+	header := make([]byte, 3, 3+len(wrapped))
+	header[0] = hybridVersion
+	binary.BigEndian.PutUint16(header[1:3], uint16(len(wrapped)))
+	header = append(header, wrapped...)
+
+	payload, err := aes.New().RegisterGCMKey(string(dataKey)).Seal([]byte(message), header)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(append(header, payload...)), nil
+}
+
+// Open decrypts an envelope produced by Seal.
+func (pri *RSAPrivateKey) Open(envelope string) (string, error) {
+	privateKey, err := pri.parse()
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := base64.URLEncoding.DecodeString(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < 3 {
+		return "", ErrInvalidEnvelope
+	}
+
+	if raw[0] != hybridVersion {
+		return "", fmt.Errorf("%w: unsupported version %d", ErrInvalidEnvelope, raw[0])
+	}
+
+	end := 3 + int(binary.BigEndian.Uint16(raw[1:3]))
+	if len(raw) < end {
+		return "", ErrInvalidEnvelope
+	}
+
+	dataKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privateKey, raw[3:end], hybridLabel)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := aes.New().RegisterGCMKey(string(dataKey)).Open(raw[end:], raw[:end])
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
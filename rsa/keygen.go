@@ -0,0 +1,232 @@
+package rsa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	mrand "math/rand"
+	"sync"
+)
+
+const (
+	DefaultKeyBits = 4096
+	MinKeyBits     = 1024
+)
+
+var ErrKeyTooSmall = fmt.Errorf("rsa: key size must be at least %d bits", MinKeyBits)
+
+type genOptions struct {
+	bits   int
+	random io.Reader
+	ctx    context.Context
+	seeded bool
+}
+
+type GenOption func(*genOptions)
+
+func WithBits(bits int) GenOption {
+	return func(o *genOptions) {
+		o.bits = bits
+	}
+}
+
+// WithRandom sets the entropy source handed to crypto/rsa. Recent Go
+// releases may ignore it or mix in extra randomness, so it does not make
+// generation reproducible; use WithSeed for that.
+func WithRandom(r io.Reader) GenOption {
+	return func(o *genOptions) {
+		o.random = r
+		o.seeded = false
+	}
+}
+
+// WithContext aborts generation when ctx is done.
+func WithContext(ctx context.Context) GenOption {
+	return func(o *genOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithSeed generates the key deterministically from seed, so the same seed
+// and size always give the same key. It is meant for tests and fixtures
+// only: anyone who knows the seed can recreate the private key.
+func WithSeed(seed int64) GenOption {
+	return func(o *genOptions) {
+		o.random = mrand.New(mrand.NewSource(seed))
+		o.seeded = true
+	}
+}
+
+// RSAGenerateKeyPair generates a key pair, 4096 bits unless WithBits says
+// otherwise, and reports failures instead of returning a nil key.
+func (r *RSAPem) RSAGenerateKeyPair(opts ...GenOption) (*rsa.PrivateKey, *rsa.PublicKey, error) {
+	o := genOptions{
+		bits:   DefaultKeyBits,
+		random: rand.Reader,
+		ctx:    context.Background(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.bits < MinKeyBits {
+		return nil, nil, ErrKeyTooSmall
+	}
+
+	if err := o.ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if o.seeded {
+		priv, err := generateSeededKey(o.ctx, o.random, o.bits)
+		if err != nil {
+			return nil, nil, err
+		}
+		return priv, &priv.PublicKey, nil
+	}
+
+	type result struct {
+		priv *rsa.PrivateKey
+		err  error
+	}
+
+	// crypto/rsa cannot be interrupted; the goroutine finishes in the
+	// background and its result is dropped once ctx is done.
+	done := make(chan result, 1)
+	go func() {
+		priv, err := rsa.GenerateKey(o.random, o.bits)
+		done <- result{priv, err}
+	}()
+
+	select {
+	case <-o.ctx.Done():
+		return nil, nil, o.ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return nil, nil, res.err
+		}
+		return res.priv, &res.priv.PublicKey, nil
+	}
+}
+
+// generateSeededKey builds a two-prime key using only random as entropy,
+// unlike rsa.GenerateKey which deliberately avoids being deterministic.
+func generateSeededKey(ctx context.Context, random io.Reader, bits int) (*rsa.PrivateKey, error) {
+	e := big.NewInt(65537)
+	one := big.NewInt(1)
+
+	for {
+		p, err := seededPrime(ctx, random, (bits+1)/2)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := seededPrime(ctx, random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+
+		phi := new(big.Int).Mul(new(big.Int).Sub(p, one), new(big.Int).Sub(q, one))
+		d := new(big.Int).ModInverse(e, phi)
+		if d == nil {
+			continue
+		}
+
+		priv := &rsa.PrivateKey{
+			PublicKey: rsa.PublicKey{N: n, E: int(e.Int64())},
+			D:         d,
+			Primes:    []*big.Int{p, q},
+		}
+		priv.Precompute()
+
+		if err := priv.Validate(); err != nil {
+			return nil, err
+		}
+		return priv, nil
+	}
+}
+
+func seededPrime(ctx context.Context, random io.Reader, bits int) (*big.Int, error) {
+	buf := make([]byte, (bits+7)/8)
+	excess := uint(len(buf)*8 - bits)
+	p := new(big.Int)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, err
+		}
+
+		// Clear the bits above the size and set the top two, so the product
+		// of two such primes has exactly the requested length.
+		buf[0] &= byte(0xFF >> excess)
+		if excess >= 7 {
+			buf[0] |= 1
+			buf[1] |= 0x80
+		} else {
+			buf[0] |= 0xC0 >> excess
+		}
+		buf[len(buf)-1] |= 1
+
+		p.SetBytes(buf)
+		if p.ProbablyPrime(20) {
+			return p, nil
+		}
+	}
+}
+
+// KeyPool caches deterministic keys so test suites pay for generation once
+// per process. Keys are derived from their size and index, so they are also
+// stable across runs. It must never be used for production keys.
+type KeyPool struct {
+	mu   sync.Mutex
+	pem  *RSAPem
+	keys map[[2]int]*rsa.PrivateKey
+}
+
+func NewKeyPool() *KeyPool {
+	return &KeyPool{
+		pem:  NewPem(),
+		keys: make(map[[2]int]*rsa.PrivateKey),
+	}
+}
+
+// Key returns the index-th cached key of the given size, generating it on
+// first use. Distinct indexes give distinct keys.
+func (p *KeyPool) Key(bits, index int) (*rsa.PrivateKey, error) {
+	if index < 0 {
+		return nil, errors.New("rsa: key pool index must not be negative")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := [2]int{bits, index}
+	if priv, ok := p.keys[id]; ok {
+		return priv, nil
+	}
+
+	priv, _, err := p.pem.RSAGenerateKeyPair(WithBits(bits), WithSeed(int64(bits)<<32|int64(index)))
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys[id] = priv
+	return priv, nil
+}
@@ -0,0 +1,76 @@
+package rsa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSAGenerateKeyPair(t *testing.T) {
+	r := NewPem()
+
+	priv, pub, err := r.RSAGenerateKeyPair(WithBits(2048))
+	require.NoError(t, err)
+	assert.Equal(t, 2048, pub.N.BitLen())
+	assert.NoError(t, priv.Validate())
+
+	_, _, err = r.RSAGenerateKeyPair(WithBits(512))
+	assert.ErrorIs(t, err, ErrKeyTooSmall)
+}
+
+func TestRSAGenerateKeyPairSeeded(t *testing.T) {
+	r := NewPem()
+
+	for _, bits := range []int{1024, 1031, 2048} {
+		a, _, err := r.RSAGenerateKeyPair(WithBits(bits), WithSeed(7))
+		require.NoError(t, err)
+		assert.Equal(t, bits, a.N.BitLen())
+
+		b, _, err := r.RSAGenerateKeyPair(WithBits(bits), WithSeed(7))
+		require.NoError(t, err)
+		assert.True(t, a.Equal(b), "same seed must give the same key")
+
+		c, _, err := r.RSAGenerateKeyPair(WithBits(bits), WithSeed(8))
+		require.NoError(t, err)
+		assert.False(t, a.Equal(c), "different seeds must give different keys")
+	}
+}
+
+func TestRSAGenerateKeyPairContext(t *testing.T) {
+	r := NewPem()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := r.RSAGenerateKeyPair(WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, _, err = r.RSAGenerateKeyPair(WithContext(ctx), WithBits(8192), WithSeed(1))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestKeyPool(t *testing.T) {
+	pool := NewKeyPool()
+
+	a, err := pool.Key(1024, 0)
+	require.NoError(t, err)
+
+	again, err := pool.Key(1024, 0)
+	require.NoError(t, err)
+	assert.Same(t, a, again)
+
+	b, err := pool.Key(1024, 1)
+	require.NoError(t, err)
+	assert.False(t, a.Equal(b))
+
+	fresh, err := NewKeyPool().Key(1024, 0)
+	require.NoError(t, err)
+	assert.True(t, a.Equal(fresh), "pool keys must be stable across pools")
+
+	_, err = pool.Key(1024, -1)
+	assert.Error(t, err)
+}
@@ -1,11 +1,17 @@
 package rsa
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
-	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
+	"errors"
+)
+
+var (
+	ErrInvalidPem      = errors.New("failed to parse PEM block containing the key")
+	ErrNotRSAKey       = errors.New("Key type is not RSA")
+	ErrHashUnavailable = errors.New("rsa: hash function not available")
 )
 
 type RSA struct{}
@@ -20,6 +26,7 @@ type RSAPublicKey struct {
 
 type RSAPrivateKey struct {
 	PrivateKey string
+	password   string
 }
 
 func (r *RSA) PublicKey(publicKey string) *RSAPublicKey {
@@ -34,18 +41,20 @@ func (r *RSA) PrivateKey(privateKey string) *RSAPrivateKey {
 	}
 }
 
-func (pub *RSAPublicKey) Encrypt(encryptStr string) (string, error) {
-	// pem
-	block, _ := pem.Decode([]byte(pub.PublicKey))
+// PrivateKeyWithPassword is PrivateKey for password-encrypted PKCS#8 keys.
+func (r *RSA) PrivateKeyWithPassword(privateKey, password string) *RSAPrivateKey {
+	return &RSAPrivateKey{
+		PrivateKey: privateKey,
+		password:   password,
+	}
+}
 
-	// x509
-	publicKeyInterface, err := x509.ParsePKIXPublicKey(block.Bytes)
+func (pub *RSAPublicKey) Encrypt(encryptStr string) (string, error) {
+	publicKey, err := pub.parse()
 	if err != nil {
 		return "", err
 	}
 
-	publicKey := publicKeyInterface.(*rsa.PublicKey)
-
 	encryptedStr, err := rsa.EncryptPKCS1v15(rand.Reader, publicKey, []byte(encryptStr))
 	if err != nil {
 		return "", err
@@ -55,14 +64,11 @@ func (pub *RSAPublicKey) Encrypt(encryptStr string) (string, error) {
 }
 
 func (pri *RSAPrivateKey) Decrypt(decryptStr string) (string, error) {
-	// pem
-	block, _ := pem.Decode([]byte(pri.PrivateKey))
-
-	// X509
-	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	privateKey, err := pri.parse()
 	if err != nil {
 		return "", err
 	}
+
 	decryptBytes, err := base64.URLEncoding.DecodeString(decryptStr)
 	if err != nil {
 		return "", err
@@ -75,3 +81,55 @@ func (pri *RSAPrivateKey) Decrypt(decryptStr string) (string, error) {
 
 	return string(decrypted), nil
 }
+
+// EncryptOAEP encrypts with RSA-OAEP using hash for both OAEP and MGF1.
+// label is optional and must be supplied again on decryption.
+func (pub *RSAPublicKey) EncryptOAEP(encryptStr string, hash crypto.Hash, label []byte) (string, error) {
+	if !hash.Available() {
+		return "", ErrHashUnavailable
+	}
+
+	publicKey, err := pub.parse()
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := rsa.EncryptOAEP(hash.New(), rand.Reader, publicKey, []byte(encryptStr), label)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(encrypted), nil
+}
+
+func (pri *RSAPrivateKey) DecryptOAEP(decryptStr string, hash crypto.Hash, label []byte) (string, error) {
+	if !hash.Available() {
+		return "", ErrHashUnavailable
+	}
+
+	privateKey, err := pri.parse()
+	if err != nil {
+		return "", err
+	}
+
+	decryptBytes, err := base64.URLEncoding.DecodeString(decryptStr)
+	if err != nil {
+		return "", err
+	}
+
+	decrypted, err := rsa.DecryptOAEP(hash.New(), rand.Reader, privateKey, decryptBytes, label)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decrypted), nil
+}
+
+// parse accepts every format understood by RSAPem.RSAParsePublicKeyFromPem.
+func (pub *RSAPublicKey) parse() (*rsa.PublicKey, error) {
+	return NewPem().RSAParsePublicKeyFromPem(pub.PublicKey)
+}
+
+func (pri *RSAPrivateKey) parse() (*rsa.PrivateKey, error) {
+	return NewPem().RSAParsePrivateKeyFromPemWithPassword(pri.PrivateKey, pri.password)
+}
@@ -1,12 +1,17 @@
 package rsa
 
 import (
+	"crypto"
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
+var testKeys = NewKeyPool()
+
 type RSASuite struct {
 	suite.Suite
 	pem          *RSAPem
@@ -21,7 +26,9 @@ func (suite *RSASuite) SetupTest() {
 	suite.pem = NewPem()
 	suite.rsa = New()
 
-	priv, pub := suite.pem.RSAGenKeyPair()
+	priv, err := testKeys.Key(2048, 0)
+	suite.Require().NoError(err)
+	pub := &priv.PublicKey
 
 	strPub, err := suite.pem.RSAExportPublicKeyAsPem(pub)
 	if err != nil {
@@ -70,6 +77,70 @@ func (suite *RSASuite) TestDecrypt() {
 	assert.Equal(suite.T(), suite.toEncrypt, str)
 }
 
+func (suite *RSASuite) TestEncryptDecryptOAEP() {
+	label := []byte("orders")
+
+	enc, err := suite.rsa.PublicKey(suite.publicKey).EncryptOAEP(suite.toEncrypt, crypto.SHA256, label)
+	suite.Require().NoError(err)
+
+	p := suite.rsa.PrivateKey(suite.privateKey)
+	dec, err := p.DecryptOAEP(enc, crypto.SHA256, label)
+	suite.Require().NoError(err)
+	suite.Equal(suite.toEncrypt, dec)
+
+	_, err = p.DecryptOAEP(enc, crypto.SHA256, []byte("other"))
+	suite.Error(err)
+
+	_, err = p.DecryptOAEP(enc, crypto.SHA512, label)
+	suite.Error(err)
+}
+
+func (suite *RSASuite) TestSignVerify() {
+	payload := `{"event":"payment.settled","amount":1000}`
+	pri := suite.rsa.PrivateKey(suite.privateKey)
+	pub := suite.rsa.PublicKey(suite.publicKey)
+
+	for _, scheme := range []SignScheme{SchemePSS, SchemePKCS1v15} {
+		sig, err := pri.Sign(payload, scheme, crypto.SHA256)
+		suite.Require().NoError(err)
+
+		suite.NoError(pub.Verify(payload, sig, scheme, crypto.SHA256))
+		suite.Error(pub.Verify(payload+" ", sig, scheme, crypto.SHA256))
+		suite.Error(pub.Verify(payload, sig, scheme, crypto.SHA512))
+	}
+
+	_, err := pri.Sign(payload, SignScheme(99), crypto.SHA256)
+	suite.Error(err)
+}
+
+func (suite *RSASuite) TestSealOpen() {
+	doc := `{"partner":"bank","items":[` + strings.Repeat(`{"id":1,"amount":100},`, 200) + `{}]}`
+
+	envelope, err := suite.rsa.PublicKey(suite.publicKey).Seal(doc)
+	suite.Require().NoError(err)
+
+	p := suite.rsa.PrivateKey(suite.privateKey)
+	opened, err := p.Open(envelope)
+	suite.Require().NoError(err)
+	suite.Equal(doc, opened)
+
+	raw, _ := base64.URLEncoding.DecodeString(envelope)
+	raw[len(raw)-1] ^= 1
+	_, err = p.Open(base64.URLEncoding.EncodeToString(raw))
+	suite.Error(err)
+
+	_, err = p.Open(base64.URLEncoding.EncodeToString([]byte{1, 0xff, 0xff}))
+	suite.ErrorIs(err, ErrInvalidEnvelope)
+}
+
+func (suite *RSASuite) TestInvalidPem() {
+	_, err := suite.rsa.PublicKey("not a pem").EncryptOAEP("x", crypto.SHA256, nil)
+	suite.ErrorIs(err, ErrInvalidPem)
+
+	_, err = suite.rsa.PrivateKey("not a pem").Sign("x", SchemePSS, crypto.SHA256)
+	suite.ErrorIs(err, ErrInvalidPem)
+}
+
 func TestRSASuite(t *testing.T) {
 	suite.Run(t, new(RSASuite))
 }
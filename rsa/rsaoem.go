@@ -1,49 +1,124 @@
 package rsa
 
 import (
-	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	pemTypeRSAPrivateKey       = "RSA PRIVATE KEY"
+	pemTypePrivateKey          = "PRIVATE KEY"
+	pemTypeEncryptedPrivateKey = "ENCRYPTED PRIVATE KEY"
+	pemTypeRSAPublicKey        = "RSA PUBLIC KEY"
+	pemTypePublicKey           = "PUBLIC KEY"
 )
 
+var ErrPasswordRequired = errors.New("rsa: key is encrypted, a password is required")
+
 type RSAPem struct{}
 
 func NewPem() *RSAPem {
 	return &RSAPem{}
 }
 
+// RSAGenKeyPair generates a 4096 bit key pair. It returns nil keys if
+// generation fails; use RSAGenerateKeyPair to get the error.
 func (r *RSAPem) RSAGenKeyPair() (*rsa.PrivateKey, *rsa.PublicKey) {
-	privkey, _ := rsa.GenerateKey(rand.Reader, 4096)
-	return privkey, &privkey.PublicKey
+	privkey, pubkey, _ := r.RSAGenerateKeyPair()
+	return privkey, pubkey
 }
 
+// RSAExportPrivateKeyAsPem exports privkey as PKCS#1 ("RSA PRIVATE KEY").
 func (r *RSAPem) RSAExportPrivateKeyAsPem(privkey *rsa.PrivateKey) string {
 	privkey_bytes := x509.MarshalPKCS1PrivateKey(privkey)
 	privkey_pem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
+			Type:  pemTypeRSAPrivateKey,
 			Bytes: privkey_bytes,
 		},
 	)
 	return string(privkey_pem)
 }
 
+// RSAExportPrivateKeyAsPKCS8Pem exports privkey as PKCS#8 ("PRIVATE KEY").
+func (r *RSAPem) RSAExportPrivateKeyAsPKCS8Pem(privkey *rsa.PrivateKey) (string, error) {
+	privkey_bytes, err := x509.MarshalPKCS8PrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: privkey_bytes})), nil
+}
+
+// RSAExportPrivateKeyAsEncryptedPem exports privkey as password-encrypted
+// PKCS#8 ("ENCRYPTED PRIVATE KEY") using PBKDF2-HMAC-SHA256 and AES-256-CBC.
+func (r *RSAPem) RSAExportPrivateKeyAsEncryptedPem(privkey *rsa.PrivateKey, password string) (string, error) {
+	privkey_bytes, err := x509.MarshalPKCS8PrivateKey(privkey)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := encryptPKCS8(privkey_bytes, password)
+	if err != nil {
+		return "", err
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypeEncryptedPrivateKey, Bytes: encrypted})), nil
+}
+
+// RSAParsePrivateKeyFromPem accepts PKCS#1 and PKCS#8 keys. Encrypted keys
+// return ErrPasswordRequired; use RSAParsePrivateKeyFromPemWithPassword.
 func (r *RSAPem) RSAParsePrivateKeyFromPem(str string) (*rsa.PrivateKey, error) {
+	return r.RSAParsePrivateKeyFromPemWithPassword(str, "")
+}
+
+// RSAParsePrivateKeyFromPemWithPassword accepts PKCS#1, PKCS#8 and
+// password-encrypted PKCS#8 keys. password is ignored for unencrypted keys.
+func (r *RSAPem) RSAParsePrivateKeyFromPemWithPassword(str, password string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode([]byte(str))
 	if block == nil {
-		return nil, errors.New("failed to parse PEM block containing the key")
+		return nil, ErrInvalidPem
+	}
+
+	der := block.Bytes
+	if block.Type == pemTypeEncryptedPrivateKey {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+
+		var err error
+		if der, err = decryptPKCS8(der, password); err != nil {
+			return nil, err
+		}
+	}
+
+	if priv, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return priv, nil
 	}
 
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	key, err := x509.ParsePKCS8PrivateKey(der)
 	if err != nil {
+		if block.Type == pemTypeEncryptedPrivateKey {
+			return nil, ErrIncorrectPassword
+		}
 		return nil, err
 	}
 
+	priv, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrNotRSAKey, key)
+	}
+
 	return priv, nil
 }
 
+// RSAExportPublicKeyAsPem exports pubkey as PKIX ("PUBLIC KEY").
 func (r *RSAPem) RSAExportPublicKeyAsPem(pubkey *rsa.PublicKey) (string, error) {
 	pubkey_bytes, err := x509.MarshalPKIXPublicKey(pubkey)
 	if err != nil {
@@ -51,7 +126,7 @@ func (r *RSAPem) RSAExportPublicKeyAsPem(pubkey *rsa.PublicKey) (string, error)
 	}
 	pubkey_pem := pem.EncodeToMemory(
 		&pem.Block{
-			Type:  "RSA PUBLIC KEY",
+			Type:  pemTypePublicKey,
 			Bytes: pubkey_bytes,
 		},
 	)
@@ -59,10 +134,39 @@ func (r *RSAPem) RSAExportPublicKeyAsPem(pubkey *rsa.PublicKey) (string, error)
 	return string(pubkey_pem), nil
 }
 
+// RSAExportPublicKeyAsPKCS1Pem exports pubkey as PKCS#1 ("RSA PUBLIC KEY").
+func (r *RSAPem) RSAExportPublicKeyAsPKCS1Pem(pubkey *rsa.PublicKey) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  pemTypeRSAPublicKey,
+		Bytes: x509.MarshalPKCS1PublicKey(pubkey),
+	}))
+}
+
+// RSAExportPublicKeyAsSSH exports pubkey in OpenSSH authorized_keys format.
+func (r *RSAPem) RSAExportPublicKeyAsSSH(pubkey *rsa.PublicKey) (string, error) {
+	sshKey, err := ssh.NewPublicKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(ssh.MarshalAuthorizedKey(sshKey)), nil
+}
+
+// RSAParsePublicKeyFromPem accepts PKIX and PKCS#1 PEM blocks as well as
+// OpenSSH "ssh-rsa" public keys. PKIX keys labelled "RSA PUBLIC KEY", as
+// older versions of RSAExportPublicKeyAsPem wrote them, are also accepted.
 func (r *RSAPem) RSAParsePublicKeyFromPem(str string) (*rsa.PublicKey, error) {
+	if strings.HasPrefix(strings.TrimSpace(str), "ssh-") {
+		return parseSSHPublicKey(str)
+	}
+
 	block, _ := pem.Decode([]byte(str))
 	if block == nil {
-		return nil, errors.New("failed to parse PEM block containing the key")
+		return nil, ErrInvalidPem
+	}
+
+	if pub, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return pub, nil
 	}
 
 	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
@@ -75,5 +179,24 @@ func (r *RSAPem) RSAParsePublicKeyFromPem(str string) (*rsa.PublicKey, error) {
 		return pub, nil
 	}
 
-	return nil, errors.New("Key type is not RSA")
+	return nil, ErrNotRSAKey
+}
+
+func parseSSHPublicKey(str string) (*rsa.PublicKey, error) {
+	sshKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(str))
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotRSAKey, sshKey.Type())
+	}
+
+	pub, ok := cryptoKey.CryptoPublicKey().(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotRSAKey, sshKey.Type())
+	}
+
+	return pub, nil
 }
@@ -0,0 +1,87 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+)
+
+type SignScheme int
+
+const (
+	SchemePSS SignScheme = iota
+	SchemePKCS1v15
+)
+
+// Sign hashes data with hash and signs the digest, returning the signature
+// as URL-safe base64.
+func (pri *RSAPrivateKey) Sign(data string, scheme SignScheme, hash crypto.Hash) (string, error) {
+	privateKey, err := pri.parse()
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := digest(data, hash)
+	if err != nil {
+		return "", err
+	}
+
+	var signature []byte
+	switch scheme {
+	case SchemePSS:
+		signature, err = rsa.SignPSS(rand.Reader, privateKey, hash, digest,
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case SchemePKCS1v15:
+		signature, err = rsa.SignPKCS1v15(rand.Reader, privateKey, hash, digest)
+	default:
+		return "", fmt.Errorf("rsa: unknown signature scheme %d", scheme)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks a signature produced by Sign. A nil error means the
+// signature is valid.
+func (pub *RSAPublicKey) Verify(data, signature string, scheme SignScheme, hash crypto.Hash) error {
+	publicKey, err := pub.parse()
+	if err != nil {
+		return err
+	}
+
+	digest, err := digest(data, hash)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.URLEncoding.DecodeString(signature)
+	if err != nil {
+		return err
+	}
+
+	switch scheme {
+	case SchemePSS:
+		// Auto salt length also accepts signatures from tools that use the
+		// maximum salt length.
+		return rsa.VerifyPSS(publicKey, hash, digest, sig,
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	case SchemePKCS1v15:
+		return rsa.VerifyPKCS1v15(publicKey, hash, digest, sig)
+	}
+
+	return fmt.Errorf("rsa: unknown signature scheme %d", scheme)
+}
+
+func digest(data string, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+
+	h := hash.New()
+	h.Write([]byte(data))
+	return h.Sum(nil), nil
+}
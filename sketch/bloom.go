@@ -0,0 +1,179 @@
+package sketch
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/thiagozs/go-xutils/hash"
+)
+
+// Serialized layout: magic(4) | k(4) | m(8) | n(8) | bits as little-endian
+// uint64 words.
+const (
+	bloomMagic      = "XBF\x01"
+	bloomHeaderSize = len(bloomMagic) + 4 + 8 + 8
+	maxBloomBits    = 1 << 36
+	maxBloomHashes  = 64
+)
+
+// BloomFilter answers "possibly seen" or "definitely not seen" for string
+// keys. False positives occur at roughly the rate it was sized for; false
+// negatives never do.
+type BloomFilter struct {
+	hash *hash.Hash
+	m    uint64
+	k    uint32
+	n    uint64
+	bits []uint64
+}
+
+// NewBloomFilter sizes a filter for expected keys at the false-positive
+// rate fpRate, which must be between 0 and 1.
+func (s *Sketch) NewBloomFilter(expected uint64, fpRate float64) (*BloomFilter, error) {
+	if expected == 0 || !(fpRate > 0 && fpRate < 1) {
+		return nil, fmt.Errorf("%w: bloom filter n=%d p=%g", ErrInvalidParams, expected, fpRate)
+	}
+
+	m := math.Ceil(-float64(expected) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(expected) * math.Ln2)
+
+	if m > maxBloomBits {
+		return nil, fmt.Errorf("%w: bloom filter needs %g bits", ErrInvalidParams, m)
+	}
+
+	return &BloomFilter{
+		hash: s.hash,
+		m:    uint64(m),
+		k:    uint32(min(max(k, 1), maxBloomHashes)),
+		bits: make([]uint64, (uint64(m)+63)/64),
+	}, nil
+}
+
+func (b *BloomFilter) Add(key string) {
+	b.TestAndAdd(key)
+}
+
+// Test reports whether key may have been added.
+func (b *BloomFilter) Test(key string) bool {
+	h1, h2 := b.hash.Murmur3_128(key, 0)
+	for i := uint32(0); i < b.k; i++ {
+		idx := b.index(h1, h2, i)
+		if b.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TestAndAdd adds key and reports whether it may have been present before,
+// which is the single call a streaming dedupe needs.
+func (b *BloomFilter) TestAndAdd(key string) bool {
+	h1, h2 := b.hash.Murmur3_128(key, 0)
+
+	present := true
+	for i := uint32(0); i < b.k; i++ {
+		idx := b.index(h1, h2, i)
+		word, mask := idx/64, uint64(1)<<(idx%64)
+		if b.bits[word]&mask == 0 {
+			present = false
+			b.bits[word] |= mask
+		}
+	}
+
+	if !present {
+		b.n++
+	}
+	return present
+}
+
+// index uses Kirsch-Mitzenmacher double hashing to derive k positions from
+// one 128-bit hash.
+func (b *BloomFilter) index(h1, h2 uint64, i uint32) uint64 {
+	return (h1 + uint64(i)*h2) % b.m
+}
+
+// Count returns the number of keys added that were not already reported
+// as present.
+func (b *BloomFilter) Count() uint64 {
+	return b.n
+}
+
+// M returns the number of bits in the filter.
+func (b *BloomFilter) M() uint64 {
+	return b.m
+}
+
+// K returns the number of hash functions.
+func (b *BloomFilter) K() uint32 {
+	return b.k
+}
+
+// FalsePositiveRate estimates the current false-positive probability from
+// the number of keys added.
+func (b *BloomFilter) FalsePositiveRate() float64 {
+	return math.Pow(1-math.Exp(-float64(b.k)*float64(b.n)/float64(b.m)), float64(b.k))
+}
+
+// Merge adds every key of other to b. Both filters must have the same m and
+// k. Count becomes the sum of both counts, so keys in both are counted twice.
+func (b *BloomFilter) Merge(other *BloomFilter) error {
+	if b.m != other.m || b.k != other.k {
+		return fmt.Errorf("%w: bloom filter m=%d k=%d and m=%d k=%d", ErrIncompatible, b.m, b.k, other.m, other.k)
+	}
+
+	for i := range b.bits {
+		b.bits[i] |= other.bits[i]
+	}
+	b.n += other.n
+	return nil
+}
+
+func (b *BloomFilter) Reset() {
+	clear(b.bits)
+	b.n = 0
+}
+
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	out := make([]byte, bloomHeaderSize, bloomHeaderSize+8*len(b.bits))
+	copy(out, bloomMagic)
+	binary.BigEndian.PutUint32(out[4:], b.k)
+	binary.BigEndian.PutUint64(out[8:], b.m)
+	binary.BigEndian.PutUint64(out[16:], b.n)
+
+	for _, w := range b.bits {
+		out = binary.LittleEndian.AppendUint64(out, w)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary restores a filter written by MarshalBinary. A zero
+// BloomFilter can be used as the receiver.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize || string(data[:4]) != bloomMagic {
+		return fmt.Errorf("%w: not a bloom filter", ErrInvalidData)
+	}
+
+	k := binary.BigEndian.Uint32(data[4:])
+	m := binary.BigEndian.Uint64(data[8:])
+	n := binary.BigEndian.Uint64(data[16:])
+	if m == 0 || m > maxBloomBits || k == 0 || k > maxBloomHashes {
+		return fmt.Errorf("%w: bloom filter m=%d k=%d", ErrInvalidData, m, k)
+	}
+
+	words := data[bloomHeaderSize:]
+	if uint64(len(words)) != 8*((m+63)/64) {
+		return fmt.Errorf("%w: bloom filter has %d bytes of bits, want %d", ErrInvalidData, len(words), 8*((m+63)/64))
+	}
+
+	bits := make([]uint64, len(words)/8)
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(words[8*i:])
+	}
+
+	if b.hash == nil {
+		b.hash = hash.New()
+	}
+	b.m, b.k, b.n, b.bits = m, k, n, bits
+	return nil
+}
@@ -0,0 +1,114 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	s := New()
+
+	bf, err := s.NewBloomFilter(10000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bf.M() != 95851 || bf.K() != 7 {
+		t.Errorf("sized to m=%d k=%d; want m=95851 k=7", bf.M(), bf.K())
+	}
+
+	for i := 0; i < 10000; i++ {
+		bf.Add(fmt.Sprintf("%011d", i))
+	}
+	for i := 0; i < 10000; i++ {
+		if !bf.Test(fmt.Sprintf("%011d", i)) {
+			t.Fatalf("false negative for %d", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 10000; i < 110000; i++ {
+		if bf.Test(fmt.Sprintf("%011d", i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 100000; rate > 0.015 {
+		t.Errorf("false-positive rate %.4f; want about 0.01", rate)
+	}
+	if rate := bf.FalsePositiveRate(); rate < 0.005 || rate > 0.015 {
+		t.Errorf("FalsePositiveRate = %.4f; want about 0.01", rate)
+	}
+	if !bf.TestAndAdd("00000000000") {
+		t.Error("TestAndAdd should report a key that was already added")
+	}
+
+	bf.Reset()
+	if bf.Test("00000000001") || bf.Count() != 0 {
+		t.Error("Reset should clear the filter")
+	}
+}
+
+func TestBloomFilterSerialization(t *testing.T) {
+	s := New()
+
+	bf, _ := s.NewBloomFilter(1000, 0.001)
+	for _, key := range []string{"11444777000161", "52998224725", "98765432100"} {
+		bf.Add(key)
+	}
+
+	data, err := bf.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var restored BloomFilter
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.M() != bf.M() || restored.K() != bf.K() || restored.Count() != 3 {
+		t.Errorf("restored m=%d k=%d n=%d", restored.M(), restored.K(), restored.Count())
+	}
+	if !restored.Test("52998224725") || restored.Test("00000000000") {
+		t.Error("restored filter answers differently")
+	}
+
+	for _, bad := range [][]byte{nil, []byte("XBF\x02"), data[:len(data)-1]} {
+		if err := restored.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidData) {
+			t.Errorf("UnmarshalBinary(%d bytes) error = %v; want ErrInvalidData", len(bad), err)
+		}
+	}
+}
+
+func TestBloomFilterMerge(t *testing.T) {
+	s := New()
+
+	a, _ := s.NewBloomFilter(100, 0.01)
+	b, _ := s.NewBloomFilter(100, 0.01)
+	a.Add("a")
+	b.Add("b")
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Test("a") || !a.Test("b") {
+		t.Error("merged filter should contain both keys")
+	}
+
+	c, _ := s.NewBloomFilter(1000, 0.01)
+	if err := a.Merge(c); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge error = %v; want ErrIncompatible", err)
+	}
+}
+
+func TestBloomFilterInvalidParams(t *testing.T) {
+	s := New()
+
+	for _, tt := range []struct {
+		n uint64
+		p float64
+	}{{0, 0.01}, {100, 0}, {100, 1}, {1 << 40, 1e-9}} {
+		if _, err := s.NewBloomFilter(tt.n, tt.p); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("NewBloomFilter(%d, %g) error = %v; want ErrInvalidParams", tt.n, tt.p, err)
+		}
+	}
+}
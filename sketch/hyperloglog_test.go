@@ -0,0 +1,84 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	s := New()
+
+	for _, n := range []int{0, 10, 1000, 100000} {
+		hll, err := s.NewHyperLogLog(DefaultPrecision)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < n; i++ {
+			key := fmt.Sprintf("%011d", i)
+			hll.Add(key)
+			hll.Add(key)
+		}
+
+		got := float64(hll.Count())
+		if n == 0 {
+			if got != 0 {
+				t.Errorf("Count on empty = %v; want 0", got)
+			}
+			continue
+		}
+		if relErr := math.Abs(got-float64(n)) / float64(n); relErr > 0.03 {
+			t.Errorf("Count = %v for %d distinct keys; error %.3f", got, n, relErr)
+		}
+	}
+
+	if _, err := s.NewHyperLogLog(MaxPrecision + 1); !errors.Is(err, ErrInvalidParams) {
+		t.Errorf("NewHyperLogLog error = %v; want ErrInvalidParams", err)
+	}
+}
+
+func TestHyperLogLogMergeAndSerialization(t *testing.T) {
+	s := New()
+
+	a, _ := s.NewHyperLogLog(12)
+	b, _ := s.NewHyperLogLog(12)
+	for i := 0; i < 6000; i++ {
+		a.Add(fmt.Sprintf("%d", i))
+		b.Add(fmt.Sprintf("%d", i+4000))
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := float64(a.Count()); math.Abs(got-10000)/10000 > 0.05 {
+		t.Errorf("merged Count = %v; want about 10000", got)
+	}
+
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var restored HyperLogLog
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Count() != a.Count() || restored.Precision() != 12 {
+		t.Errorf("restored Count = %d; want %d", restored.Count(), a.Count())
+	}
+
+	bad := append([]byte(nil), data...)
+	bad[len(bad)-1] = 64
+	if err := restored.UnmarshalBinary(bad); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary error = %v; want ErrInvalidData", err)
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidData) {
+		t.Errorf("UnmarshalBinary error = %v; want ErrInvalidData", err)
+	}
+
+	c, _ := s.NewHyperLogLog(10)
+	if err := a.Merge(c); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Merge error = %v; want ErrIncompatible", err)
+	}
+}
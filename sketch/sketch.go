@@ -0,0 +1,24 @@
+package sketch
+
+import (
+	"errors"
+
+	"github.com/thiagozs/go-xutils/hash"
+)
+
+var (
+	ErrInvalidParams = errors.New("sketch: invalid parameters")
+	ErrIncompatible  = errors.New("sketch: incompatible sketches")
+	ErrInvalidData   = errors.New("sketch: invalid serialized data")
+)
+
+// Sketch builds approximate data structures that trade a bounded error for
+// bounded memory. The structures it returns are not safe for concurrent
+// use.
+type Sketch struct {
+	hash *hash.Hash
+}
+
+func New() *Sketch {
+	return &Sketch{hash: hash.New()}
+}
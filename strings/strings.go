@@ -7,6 +7,7 @@ import (
 	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/thiagozs/go-xutils/id"
 	"github.com/thiagozs/go-xutils/randutil"
 )
 
@@ -25,6 +26,8 @@ var (
 	// use global rand seeded in xutils.init()
 	// seededRand removed to avoid per-package RNG sources
 	stopWordsMap = map[string]struct{}{}
+	// shared so sortable slugs stay monotonic across Strings values
+	slugIDs = id.New()
 )
 
 func init() {
@@ -48,6 +51,15 @@ func (s *Strings) GenerateUniqueSlug(input string) string {
 	return slug
 }
 
+// GenerateSortableSlug prefixes the slug with a lowercase ULID, so slugs do
+// not collide and sort by creation time whatever the input, e.g. for use as
+// DB keys.
+func (s *Strings) GenerateSortableSlug(input string) string {
+	slug := strings.Trim(slugReg.ReplaceAllString(strings.ToLower(input), "-"), "-")
+
+	return fmt.Sprintf("%s-%s", strings.ToLower(slugIDs.ULID()), slug)
+}
+
 // ToCamelCase converts a string to camel case
 func (s *Strings) ToCamelCase(str string) string {
 	return s.toCamelCase(str)
@@ -62,6 +62,21 @@ func (suite *StringsSuite) TestGenerateUniqueSlug() {
 	}
 }
 
+func (suite *StringsSuite) TestGenerateSortableSlug() {
+	slugRegex := regexp.MustCompile("^[0-9a-hjkmnp-tv-z]{26}-(hello-world|a-title)$")
+
+	// Alternate inputs so the order cannot come from the slug text.
+	inputs := []string{"Hello, World!", "A title"}
+
+	previous := ""
+	for i := 0; i < 100; i++ {
+		slug := suite.str.GenerateSortableSlug(inputs[i%2])
+		assert.Regexp(suite.T(), slugRegex, slug)
+		assert.Greater(suite.T(), slug, previous, "slugs should sort in creation order")
+		previous = slug
+	}
+}
+
 func (suite *StringsSuite) TestToSnakeCase() {
 
 	tests := []struct {
@@ -0,0 +1,180 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	tagName       = "xutils"
+	tagEncryptOpt = "encrypt"
+)
+
+var (
+	ErrNotStructPointer = errors.New("structs: expected a non-nil pointer to a struct")
+	ErrUnsupportedField = errors.New("structs: encrypt tag on unsupported field type")
+)
+
+// FieldCipher is satisfied by the aes ciphers, e.g. *aes.AESgcm or *aes.Keyring.
+type FieldCipher interface {
+	Encrypt(string) (string, error)
+	Decrypt(string) (string, error)
+}
+
+// EncryptFields encrypts in place every field tagged `xutils:"encrypt"`.
+// Tagged fields may be string, *string or []string; empty values are left
+// untouched. Nested structs, pointers to structs and slices of structs are
+// walked recursively; a struct reached through several pointers, or through
+// a cycle, and a string shared by several *string or []string fields are
+// transformed once. On error the struct may be left partly transformed.
+func (s *Structs) EncryptFields(i any, c FieldCipher) error {
+	return walkEncrypted(i, c.Encrypt)
+}
+
+// DecryptFields reverses EncryptFields.
+func (s *Structs) DecryptFields(i any, c FieldCipher) error {
+	return walkEncrypted(i, c.Decrypt)
+}
+
+// fieldWalker applies fn to tagged fields. visited records the structs
+// already reached through a pointer and the strings already transformed, so
+// cycles terminate and a value shared by several references is transformed
+// only once.
+type fieldWalker struct {
+	fn      func(string) (string, error)
+	visited map[visitKey]struct{}
+}
+
+// visitKey includes the type because a struct and its first field share an
+// address.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func walkEncrypted(i any, fn func(string) (string, error)) error {
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return ErrNotStructPointer
+	}
+
+	w := &fieldWalker{fn: fn, visited: map[visitKey]struct{}{}}
+	w.firstVisit(v)
+	return w.walkStruct(v.Elem())
+}
+
+// firstVisit marks the value ptr points to and reports whether it had not
+// been seen before.
+func (w *fieldWalker) firstVisit(ptr reflect.Value) bool {
+	key := visitKey{ptr: ptr.Pointer(), typ: ptr.Type()}
+	if _, ok := w.visited[key]; ok {
+		return false
+	}
+	w.visited[key] = struct{}{}
+	return true
+}
+
+func (w *fieldWalker) walkStruct(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := v.Type().Field(i)
+
+		if !field.CanSet() {
+			continue
+		}
+
+		if hasEncryptTag(fieldType.Tag.Get(tagName)) {
+			if err := w.transformField(field); err != nil {
+				return fmt.Errorf("%s: %w", fieldType.Name, err)
+			}
+			continue
+		}
+
+		if err := w.walkValue(field); err != nil {
+			return fmt.Errorf("%s.%w", fieldType.Name, err)
+		}
+	}
+	return nil
+}
+
+// walkValue descends into untagged values that may contain tagged fields.
+func (w *fieldWalker) walkValue(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		return w.walkStruct(v)
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr && elem.Elem().Kind() == reflect.Struct {
+			if !w.firstVisit(elem) {
+				return nil
+			}
+			return w.walkStruct(elem.Elem())
+		}
+		if elem.Kind() == reflect.Struct && elem.CanSet() {
+			if v.Kind() == reflect.Ptr && !w.firstVisit(v) {
+				return nil
+			}
+			return w.walkStruct(elem)
+		}
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < v.Len(); j++ {
+			if err := w.walkValue(v.Index(j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *fieldWalker) transformField(field reflect.Value) error {
+	switch {
+	case field.Kind() == reflect.String:
+		return w.transformString(field)
+	case field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.String:
+		if field.IsNil() {
+			return nil
+		}
+		return w.transformString(field.Elem())
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		for j := 0; j < field.Len(); j++ {
+			if err := w.transformString(field.Index(j)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedField, field.Type())
+}
+
+// transformString records each string by address, which also covers a
+// *string aliasing another field and slices sharing a backing array.
+func (w *fieldWalker) transformString(v reflect.Value) error {
+	if v.Len() == 0 {
+		return nil
+	}
+	if v.CanAddr() && !w.firstVisit(v.Addr()) {
+		return nil
+	}
+
+	out, err := w.fn(v.String())
+	if err != nil {
+		return err
+	}
+
+	v.SetString(out)
+	return nil
+}
+
+func hasEncryptTag(tag string) bool {
+	for _, opt := range strings.Split(tag, ",") {
+		if strings.TrimSpace(opt) == tagEncryptOpt {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package structs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thiagozs/go-xutils/aes"
+)
+
+type contact struct {
+	Phone string `xutils:"encrypt"`
+	Label string
+}
+
+type customer struct {
+	Name     string
+	CPF      string   `xutils:"encrypt"`
+	Email    *string  `xutils:"encrypt"`
+	Aliases  []string `xutils:"encrypt"`
+	Empty    string   `xutils:"encrypt"`
+	Main     contact
+	Backup   *contact
+	Contacts []contact
+	secret   string `xutils:"encrypt"`
+}
+
+func TestEncryptDecryptFields(t *testing.T) {
+	s := New()
+	c := aes.New().RegisterGCMKey("IgkibX71IEf382PTIgkibX71IEf382PT")
+
+	email := "john@example.com"
+	in := customer{
+		Name:     "John",
+		CPF:      "52998224725",
+		Email:    &email,
+		Aliases:  []string{"jd", "johnny"},
+		Main:     contact{Phone: "+5511999999999", Label: "home"},
+		Backup:   &contact{Phone: "+5511888888888"},
+		Contacts: []contact{{Phone: "+5511777777777"}},
+		secret:   "untouched",
+	}
+
+	if err := s.EncryptFields(&in, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.Name != "John" || in.Main.Label != "home" || in.secret != "untouched" {
+		t.Error("untagged fields must not change")
+	}
+
+	if in.CPF == "52998224725" || *in.Email == "john@example.com" || in.Aliases[1] == "johnny" ||
+		in.Main.Phone == "+5511999999999" || in.Backup.Phone == "+5511888888888" ||
+		in.Contacts[0].Phone == "+5511777777777" {
+		t.Fatalf("expected tagged fields to be encrypted: %+v", in)
+	}
+
+	if in.Empty != "" {
+		t.Error("expected empty field to stay empty")
+	}
+
+	if err := s.DecryptFields(&in, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.CPF != "52998224725" || *in.Email != "john@example.com" || in.Aliases[1] != "johnny" ||
+		in.Main.Phone != "+5511999999999" || in.Backup.Phone != "+5511888888888" ||
+		in.Contacts[0].Phone != "+5511777777777" {
+		t.Errorf("unexpected decrypted values: %+v", in)
+	}
+}
+
+func TestEncryptFieldsErrors(t *testing.T) {
+	s := New()
+	c := aes.New().RegisterGCMKey("IgkibX71IEf382PTIgkibX71IEf382PT")
+
+	if err := s.EncryptFields(customer{}, c); !errors.Is(err, ErrNotStructPointer) {
+		t.Errorf("expected ErrNotStructPointer, got %v", err)
+	}
+
+	bad := struct {
+		Age int `xutils:"encrypt"`
+	}{Age: 30}
+	if err := s.EncryptFields(&bad, c); !errors.Is(err, ErrUnsupportedField) {
+		t.Errorf("expected ErrUnsupportedField, got %v", err)
+	}
+
+	tampered := customer{CPF: "not-a-ciphertext"}
+	if err := s.DecryptFields(&tampered, c); err == nil {
+		t.Error("expected error decrypting invalid value")
+	}
+}
+
+type node struct {
+	Value string `xutils:"encrypt"`
+	Next  *node
+}
+
+func TestEncryptFieldsCycle(t *testing.T) {
+	s := New()
+	c := aes.New().RegisterGCMKey("IgkibX71IEf382PTIgkibX71IEf382PT")
+
+	a := &node{Value: "a"}
+	b := &node{Value: "b", Next: a}
+	a.Next = b
+
+	if err := s.EncryptFields(a, c); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DecryptFields(a, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Value != "a" || b.Value != "b" {
+		t.Errorf("expected each node transformed once, got %q and %q", a.Value, b.Value)
+	}
+}
+
+func TestEncryptFieldsSharedPointer(t *testing.T) {
+	s := New()
+	c := aes.New().RegisterGCMKey("IgkibX71IEf382PTIgkibX71IEf382PT")
+
+	shared := &contact{Phone: "+5511999999999"}
+	in := struct {
+		Home   *contact
+		Work   *contact
+		Others []*contact
+	}{Home: shared, Work: shared, Others: []*contact{shared}}
+
+	if err := s.EncryptFields(&in, c); err != nil {
+		t.Fatal(err)
+	}
+
+	plain, err := c.Decrypt(shared.Phone)
+	if err != nil || plain != "+5511999999999" {
+		t.Errorf("expected shared contact encrypted once, got %q, %v", plain, err)
+	}
+}
+
+func TestEncryptFieldsSharedStrings(t *testing.T) {
+	s := New()
+	c := aes.New().RegisterGCMKey("IgkibX71IEf382PTIgkibX71IEf382PT")
+
+	v := "52998224725"
+	sl := []string{"jd", "johnny"}
+	in := struct {
+		A *string  `xutils:"encrypt"`
+		B *string  `xutils:"encrypt"`
+		S []string `xutils:"encrypt"`
+		U []string `xutils:"encrypt"`
+	}{A: &v, B: &v, S: sl, U: sl}
+
+	if err := s.EncryptFields(&in, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if plain, err := c.Decrypt(v); err != nil || plain != "52998224725" {
+		t.Errorf("expected shared *string encrypted once, got %q, %v", plain, err)
+	}
+	for i, want := range []string{"jd", "johnny"} {
+		if plain, err := c.Decrypt(sl[i]); err != nil || plain != want {
+			t.Errorf("expected shared slice element %d encrypted once, got %q, %v", i, plain, err)
+		}
+	}
+
+	if err := s.DecryptFields(&in, c); err != nil {
+		t.Fatal(err)
+	}
+	if v != "52998224725" || sl[0] != "jd" || sl[1] != "johnny" {
+		t.Errorf("unexpected decrypted values: %q %v", v, sl)
+	}
+}
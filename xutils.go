@@ -5,17 +5,25 @@ import (
 	"github.com/thiagozs/go-xutils/bools"
 	"github.com/thiagozs/go-xutils/calc"
 	"github.com/thiagozs/go-xutils/cep"
+	"github.com/thiagozs/go-xutils/cert"
+	"github.com/thiagozs/go-xutils/chacha"
 	"github.com/thiagozs/go-xutils/cnpj"
+	"github.com/thiagozs/go-xutils/color"
 	"github.com/thiagozs/go-xutils/convs"
 	"github.com/thiagozs/go-xutils/cpf"
 	"github.com/thiagozs/go-xutils/csv"
+	"github.com/thiagozs/go-xutils/ec"
 	"github.com/thiagozs/go-xutils/email"
 	"github.com/thiagozs/go-xutils/files"
 	"github.com/thiagozs/go-xutils/geo"
 	"github.com/thiagozs/go-xutils/hash"
+	"github.com/thiagozs/go-xutils/id"
 	"github.com/thiagozs/go-xutils/ip"
+	"github.com/thiagozs/go-xutils/jwk"
+	"github.com/thiagozs/go-xutils/jwt"
 	"github.com/thiagozs/go-xutils/phone"
 	"github.com/thiagozs/go-xutils/rsa"
+	"github.com/thiagozs/go-xutils/sketch"
 	"github.com/thiagozs/go-xutils/slices"
 	"github.com/thiagozs/go-xutils/strings"
 	"github.com/thiagozs/go-xutils/structs"
@@ -43,6 +51,14 @@ type XUtils struct {
 	geo     *geo.Geo
 	cep     *cep.CEP
 	files   *files.Files
+	chacha  *chacha.ChaCha
+	jwk     *jwk.JWK
+	jwt     *jwt.JWT
+	ecPem   *ec.ECPem
+	cert    *cert.Cert
+	sketch  *sketch.Sketch
+	id      *id.ID
+	color   *color.Color
 }
 
 func New() *XUtils {
@@ -67,6 +83,14 @@ func New() *XUtils {
 		geo:     geo.New(),
 		cep:     cep.New(),
 		files:   files.New(),
+		chacha:  chacha.New(),
+		jwk:     jwk.New(),
+		jwt:     jwt.New(),
+		ecPem:   ec.NewPem(),
+		cert:    cert.New(),
+		sketch:  sketch.New(),
+		id:      id.New(),
+		color:   color.New(),
 	}
 }
 
@@ -104,6 +128,10 @@ func (x *XUtils) RSAPem() *rsa.RSAPem {
 	return x.rsaPem
 }
 
+func (x *XUtils) ECPem() *ec.ECPem {
+	return x.ecPem
+}
+
 func (x *XUtils) AES() *aes.AES {
 	return x.aes
 }
@@ -151,3 +179,31 @@ func (x *XUtils) CEP() *cep.CEP {
 func (x *XUtils) Files() *files.Files {
 	return x.files
 }
+
+func (x *XUtils) ChaCha() *chacha.ChaCha {
+	return x.chacha
+}
+
+func (x *XUtils) JWK() *jwk.JWK {
+	return x.jwk
+}
+
+func (x *XUtils) JWT() *jwt.JWT {
+	return x.jwt
+}
+
+func (x *XUtils) Cert() *cert.Cert {
+	return x.cert
+}
+
+func (x *XUtils) Sketch() *sketch.Sketch {
+	return x.sketch
+}
+
+func (x *XUtils) ID() *id.ID {
+	return x.id
+}
+
+func (x *XUtils) Color() *color.Color {
+	return x.color
+}